@@ -0,0 +1,142 @@
+package oprs
+
+import (
+	"cmp"
+	"math/big"
+
+	"github.com/kendfss/rules"
+)
+
+// Arith describes the arithmetic of a number type, so that generic code
+// can run on built-in numbers and arbitrary precision ones alike
+// Implementations never modify their arguments
+type Arith[T any] interface {
+	Zero() T
+	One() T
+	FromInt(int64) T
+	Add(a, b T) T
+	Sub(a, b T) T
+	Mul(a, b T) T
+	Div(a, b T) T // truncates towards zero for integer types
+	Neg(a T) T
+	Cmp(a, b T) int // -1, 0 or +1 as a is less than, equal to or greater than b
+}
+
+// IntArith is the arithmetic of an integer type
+type IntArith[T any] interface {
+	Arith[T]
+	Mod(a, b T) T // has the sign of a, like "%"
+}
+
+// Native is the Arith of a built-in real type
+type Native[R rules.Real] struct{}
+
+func (Native[R]) Zero() R           { return 0 }
+func (Native[R]) One() R            { return 1 }
+func (Native[R]) FromInt(i int64) R { return R(i) }
+func (Native[R]) Add(a, b R) R      { return a + b }
+func (Native[R]) Sub(a, b R) R      { return a - b }
+func (Native[R]) Mul(a, b R) R      { return a * b }
+func (Native[R]) Div(a, b R) R      { return a / b }
+func (Native[R]) Neg(a R) R         { return 0 - a }
+func (Native[R]) Cmp(a, b R) int    { return cmp.Compare(a, b) }
+
+// NativeInt is the IntArith of a built-in integer type
+type NativeInt[I rules.Int] struct {
+	Native[I]
+}
+
+func (NativeInt[I]) Mod(a, b I) I { return a % b }
+
+// BigInt is the IntArith of *big.Int
+type BigInt struct{}
+
+func (BigInt) Zero() *big.Int             { return new(big.Int) }
+func (BigInt) One() *big.Int              { return big.NewInt(1) }
+func (BigInt) FromInt(i int64) *big.Int   { return big.NewInt(i) }
+func (BigInt) Add(a, b *big.Int) *big.Int { return new(big.Int).Add(a, b) }
+func (BigInt) Sub(a, b *big.Int) *big.Int { return new(big.Int).Sub(a, b) }
+func (BigInt) Mul(a, b *big.Int) *big.Int { return new(big.Int).Mul(a, b) }
+func (BigInt) Div(a, b *big.Int) *big.Int { return new(big.Int).Quo(a, b) }
+func (BigInt) Mod(a, b *big.Int) *big.Int { return new(big.Int).Rem(a, b) }
+func (BigInt) Neg(a *big.Int) *big.Int    { return new(big.Int).Neg(a) }
+func (BigInt) Cmp(a, b *big.Int) int      { return a.Cmp(b) }
+
+// BigRat is the Arith of *big.Rat
+type BigRat struct{}
+
+func (BigRat) Zero() *big.Rat             { return new(big.Rat) }
+func (BigRat) One() *big.Rat              { return big.NewRat(1, 1) }
+func (BigRat) FromInt(i int64) *big.Rat   { return big.NewRat(i, 1) }
+func (BigRat) Add(a, b *big.Rat) *big.Rat { return new(big.Rat).Add(a, b) }
+func (BigRat) Sub(a, b *big.Rat) *big.Rat { return new(big.Rat).Sub(a, b) }
+func (BigRat) Mul(a, b *big.Rat) *big.Rat { return new(big.Rat).Mul(a, b) }
+func (BigRat) Div(a, b *big.Rat) *big.Rat { return new(big.Rat).Quo(a, b) }
+func (BigRat) Neg(a *big.Rat) *big.Rat    { return new(big.Rat).Neg(a) }
+func (BigRat) Cmp(a, b *big.Rat) int      { return a.Cmp(b) }
+
+// BigFloat is the Arith of *big.Float
+// Results are rounded to Prec bits of mantissa, or to the larger of the
+// operands' precisions if Prec is zero
+type BigFloat struct {
+	Prec uint
+}
+
+func (f BigFloat) new() *big.Float                { return new(big.Float).SetPrec(f.Prec) }
+func (f BigFloat) Zero() *big.Float               { return f.new() }
+func (f BigFloat) One() *big.Float                { return f.new().SetInt64(1) }
+func (f BigFloat) FromInt(i int64) *big.Float     { return f.new().SetInt64(i) }
+func (f BigFloat) Add(a, b *big.Float) *big.Float { return f.new().Add(a, b) }
+func (f BigFloat) Sub(a, b *big.Float) *big.Float { return f.new().Sub(a, b) }
+func (f BigFloat) Mul(a, b *big.Float) *big.Float { return f.new().Mul(a, b) }
+func (f BigFloat) Div(a, b *big.Float) *big.Float { return f.new().Quo(a, b) }
+func (f BigFloat) Neg(a *big.Float) *big.Float    { return f.new().Neg(a) }
+func (BigFloat) Cmp(a, b *big.Float) int          { return a.Cmp(b) }
+
+// DecimalArith is the Arith of Decimal
+// Add, Sub and Mul are exact, while Div rounds to Scale digits with Mode, and
+// panics on division by zero, like the big types
+type DecimalArith struct {
+	Scale int
+	Mode  Rounding
+}
+
+func (DecimalArith) Zero() Decimal            { return Decimal{} }
+func (DecimalArith) One() Decimal             { return NewDecimal(1, 0) }
+func (DecimalArith) FromInt(i int64) Decimal  { return NewDecimal(i, 0) }
+func (DecimalArith) Add(a, b Decimal) Decimal { return a.Add(b) }
+func (DecimalArith) Sub(a, b Decimal) Decimal { return a.Sub(b) }
+func (DecimalArith) Mul(a, b Decimal) Decimal { return a.Mul(b) }
+func (DecimalArith) Neg(a Decimal) Decimal    { return a.Neg() }
+func (DecimalArith) Cmp(a, b Decimal) int     { return a.Cmp(b) }
+
+func (d DecimalArith) Div(a, b Decimal) Decimal {
+	out, err := a.Quo(b, d.Scale, d.Mode)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// Fold returns a closure that accumulates a slice, from the left, into a single value
+func Fold[T, A any](op func(A, T) A, init A) func([]T) A {
+	return func(args []T) A {
+		acc := init
+		for _, arg := range args {
+			acc = op(acc, arg)
+		}
+		return acc
+	}
+}
+
+// Sum returns a closure that adds its arguments with the given arithmetic
+// the empty sum is Zero
+func Sum[T any](ar Arith[T]) func(...T) T {
+	return Variad(Fold(ar.Add, ar.Zero()))
+}
+
+// Product returns a closure that multiplies its arguments with the given arithmetic
+// the empty product is One
+func Product[T any](ar Arith[T]) func(...T) T {
+	return Variad(Fold(ar.Mul, ar.One()))
+}
@@ -0,0 +1,47 @@
+package oprs
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArith(t *testing.T) {
+	assert.Equal(t, 10, Sum[int](Native[int]{})(1, 2, 3, 4))
+	assert.Equal(t, 24, Product[int](NativeInt[int]{})(1, 2, 3, 4))
+	assert.Equal(t, -1, NativeInt[int]{}.Mod(-7, 3))
+	assert.Equal(t, 0.0, Sum[float64](Native[float64]{})())
+
+	var bi IntArith[*big.Int] = BigInt{}
+	x := ParseBigInt("18446744073709551616") // 1<<64
+	y := bi.Mul(x, x)
+	assert.Equal(t, "340282366920938463463374607431768211456", y.String())
+	assert.Equal(t, "18446744073709551616", x.String(), "arguments must not be modified")
+	assert.Equal(t, 0, bi.Cmp(bi.Div(y, x), x))
+	assert.Equal(t, int64(-1), bi.Mod(big.NewInt(-7), big.NewInt(3)).Int64())
+	assert.Equal(t, "18446744073709551619", Sum[*big.Int](bi)(x, bi.One(), bi.FromInt(2)).String())
+
+	var br Arith[*big.Rat] = BigRat{}
+	third := br.Div(br.One(), br.FromInt(3))
+	assert.Equal(t, "1", Sum[*big.Rat](br)(third, third, third).RatString())
+	assert.Equal(t, -1, br.Cmp(br.Neg(third), br.Zero()))
+
+	bf := BigFloat{Prec: 200}
+	f := bf.Div(bf.One(), bf.FromInt(3))
+	assert.Equal(t, uint(200), f.Prec())
+	assert.Equal(t, "0.33333333333333333333333333333333333333333333333333", f.Text('f', 50))
+
+	da := DecimalArith{Scale: 2, Mode: RoundHalfUp}
+	d := da.Div(da.One(), da.FromInt(3))
+	assert.Equal(t, "0.33", d.String())
+	assert.Equal(t, "0.99", Sum[Decimal](da)(d, d, d).String())
+	assert.Equal(t, "0.1089", Product[Decimal](da)(d, d, da.One()).String())
+	assert.Panics(t, func() { da.Div(d, da.Zero()) })
+}
+
+func TestFold(t *testing.T) {
+	concat := Fold(func(acc string, r rune) string { return acc + string(r) }, ">")
+	assert.Equal(t, ">abc", concat([]rune("abc")))
+	assert.Equal(t, ">", concat(nil))
+}
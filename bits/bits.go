@@ -0,0 +1,211 @@
+// Package bits offers the bit counting and manipulation functions of math/bits
+// for every integer type, along with a fixed-size Bitset
+//
+// Signed arguments are treated as their two's complement bit pattern
+package bits
+
+import (
+	"iter"
+	"math/bits"
+	"unsafe"
+
+	"github.com/kendfss/rules"
+)
+
+// Width returns the number of bits in an instance of the given type
+func Width[T rules.Int]() int {
+	return int(unsafe.Sizeof(*new(T))) * 8
+}
+
+// mask returns the bit pattern of x, zero-extended to 64 bits
+func mask[T rules.Int](x T) uint64 {
+	return uint64(x) & (^uint64(0) >> (64 - Width[T]()))
+}
+
+// PopCount returns the number of one bits in x
+func PopCount[T rules.Int](x T) int {
+	return bits.OnesCount64(mask(x))
+}
+
+// LeadingZeros returns the number of leading zero bits in x
+// the result is Width[T]() for x == 0
+func LeadingZeros[T rules.Int](x T) int {
+	return bits.LeadingZeros64(mask(x)) - (64 - Width[T]())
+}
+
+// TrailingZeros returns the number of trailing zero bits in x
+// the result is Width[T]() for x == 0
+func TrailingZeros[T rules.Int](x T) int {
+	if x == 0 {
+		return Width[T]()
+	}
+	return bits.TrailingZeros64(mask(x))
+}
+
+// Len returns the minimum number of bits required to represent x
+// the result is 0 for x == 0
+func Len[T rules.Int](x T) int {
+	return bits.Len64(mask(x))
+}
+
+// RotateLeft returns the value of x rotated left by k bits
+// To rotate right, call RotateLeft(x, -k) or RotateRight(x, k)
+func RotateLeft[T rules.Int](x T, k int) T {
+	w := Width[T]()
+	k %= w
+	if k < 0 {
+		k += w
+	}
+	if k == 0 {
+		return x
+	}
+	u := mask(x)
+	return T(u<<k | u>>(w-k))
+}
+
+// RotateRight returns the value of x rotated right by k bits
+func RotateRight[T rules.Int](x T, k int) T {
+	return RotateLeft(x, -k)
+}
+
+// Reverse returns the value of x with its bits in reversed order
+func Reverse[T rules.Int](x T) T {
+	return T(bits.Reverse64(mask(x)) >> (64 - Width[T]()))
+}
+
+// ReverseBytes returns the value of x with its bytes in reversed order
+func ReverseBytes[T rules.Int](x T) T {
+	return T(bits.ReverseBytes64(mask(x)) >> (64 - Width[T]()))
+}
+
+// fieldMask returns width one bits, starting at the given offset
+// it panics if the field does not fit in T
+func fieldMask[T rules.Int](offset, width int) uint64 {
+	if offset < 0 || width < 0 || offset+width > Width[T]() {
+		panic("oprs.bits: field out of range")
+	}
+	if width == 64 {
+		return ^uint64(0)
+	}
+	return (1<<width - 1) << offset
+}
+
+// Extract returns the width bits of x that start at the given offset, shifted down to bit 0
+// It panics if the field does not fit in T
+func Extract[T rules.Int](x T, offset, width int) T {
+	return T(mask(x) & fieldMask[T](offset, width) >> offset)
+}
+
+// Insert returns x with the width bits that start at the given offset replaced
+// by the low bits of val
+// It panics if the field does not fit in T
+func Insert[T rules.Int](x, val T, offset, width int) T {
+	m := fieldMask[T](offset, width)
+	return T(mask(x)&^m | mask(val)<<offset&m)
+}
+
+// Bitset is a set of the integers in [0, Width[T]()) stored in the bits of a single T
+// Its methods never modify the receiver, they return a new set
+type Bitset[T rules.Int] struct {
+	bits T
+}
+
+// NewBitset returns the set of the given elements
+// It panics if an element is out of range
+func NewBitset[T rules.Int](elems ...int) (out Bitset[T]) {
+	for _, e := range elems {
+		out = out.Add(e)
+	}
+	return out
+}
+
+// BitsetOf returns the set whose elements are the one bits of x
+func BitsetOf[T rules.Int](x T) Bitset[T] {
+	return Bitset[T]{x}
+}
+
+// Bits returns the underlying bit pattern
+func (b Bitset[T]) Bits() T {
+	return b.bits
+}
+
+// Cap returns the number of elements the set can hold
+func (b Bitset[T]) Cap() int {
+	return Width[T]()
+}
+
+// Len returns the number of elements in the set
+func (b Bitset[T]) Len() int {
+	return PopCount(b.bits)
+}
+
+// Has reports whether i is in the set
+func (b Bitset[T]) Has(i int) bool {
+	return i >= 0 && i < b.Cap() && mask(b.bits)>>i&1 == 1
+}
+
+// Add returns the set with i added
+// It panics if i is out of range
+func (b Bitset[T]) Add(i int) Bitset[T] {
+	return Bitset[T]{Insert(b.bits, 1, i, 1)}
+}
+
+// Remove returns the set with i removed
+// It panics if i is out of range
+func (b Bitset[T]) Remove(i int) Bitset[T] {
+	return Bitset[T]{Insert(b.bits, 0, i, 1)}
+}
+
+// Union returns the elements in either set
+func (b Bitset[T]) Union(o Bitset[T]) Bitset[T] {
+	return Bitset[T]{b.bits | o.bits}
+}
+
+// Intersect returns the elements in both sets
+func (b Bitset[T]) Intersect(o Bitset[T]) Bitset[T] {
+	return Bitset[T]{b.bits & o.bits}
+}
+
+// Difference returns the elements in b but not in o
+func (b Bitset[T]) Difference(o Bitset[T]) Bitset[T] {
+	return Bitset[T]{b.bits &^ o.bits}
+}
+
+// SymmetricDifference returns the elements in exactly one of the sets
+func (b Bitset[T]) SymmetricDifference(o Bitset[T]) Bitset[T] {
+	return Bitset[T]{b.bits ^ o.bits}
+}
+
+// Complement returns the elements in range that are not in the set
+func (b Bitset[T]) Complement() Bitset[T] {
+	return Bitset[T]{^b.bits}
+}
+
+// IsSubset reports whether every element of b is in o
+func (b Bitset[T]) IsSubset(o Bitset[T]) bool {
+	return b.bits&^o.bits == 0
+}
+
+// IsEmpty reports whether the set has no elements
+func (b Bitset[T]) IsEmpty() bool {
+	return b.bits == 0
+}
+
+// All iterates over the elements in ascending order
+func (b Bitset[T]) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for u := mask(b.bits); u != 0; u &= u - 1 {
+			if !yield(bits.TrailingZeros64(u)) {
+				return
+			}
+		}
+	}
+}
+
+// Elements returns the elements in ascending order
+func (b Bitset[T]) Elements() (out []int) {
+	for i := range b.All() {
+		out = append(out, i)
+	}
+	return out
+}
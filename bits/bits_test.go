@@ -0,0 +1,60 @@
+package bits
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounting(t *testing.T) {
+	assert.Equal(t, 8, PopCount(int8(-1)))
+	assert.Equal(t, 64, PopCount(-1))
+	assert.Equal(t, 0, LeadingZeros(int16(-1)))
+	assert.Equal(t, 16, LeadingZeros(uint16(0)))
+	assert.Equal(t, 7, LeadingZeros(int8(1)))
+	assert.Equal(t, 32, TrailingZeros(int32(0)))
+	assert.Equal(t, 7, TrailingZeros(int8(-128)))
+	assert.Equal(t, 8, Len(int8(-128)))
+	for _, x := range []uint32{0, 1, 0xdeadbeef, 1 << 31} {
+		assert.Equal(t, bits.OnesCount32(x), PopCount(x))
+		assert.Equal(t, bits.LeadingZeros32(x), LeadingZeros(x))
+		assert.Equal(t, bits.TrailingZeros32(x), TrailingZeros(x))
+		assert.Equal(t, bits.Reverse32(x), Reverse(x))
+		assert.Equal(t, bits.ReverseBytes32(x), ReverseBytes(x))
+		for k := -40; k < 40; k += 7 {
+			assert.Equal(t, bits.RotateLeft32(x, k), RotateLeft(x, k))
+		}
+	}
+}
+
+func TestManipulation(t *testing.T) {
+	assert.Equal(t, int8(-127), RotateLeft(int8(-64), 1))
+	assert.Equal(t, int8(0x40), RotateRight(int8(-128), 1))
+	assert.Equal(t, int8(1), Reverse(int8(-128)))
+	assert.Equal(t, uint16(0xbe), Extract(uint16(0xbeef), 8, 8))
+	assert.Equal(t, int8(-1), Extract(int8(-1), 0, 8))
+	assert.Equal(t, uint16(0xb00f), Insert(uint16(0xbeef), 0x00, 4, 8))
+	assert.Equal(t, int64(-1), Insert[int64](0, -1, 0, 64))
+	assert.Panics(t, func() { Extract(uint8(0), 4, 5) })
+}
+
+func TestBitset(t *testing.T) {
+	a := NewBitset[int8](0, 2, 7)
+	b := NewBitset[int8](2, 3)
+	assert.Equal(t, []int{0, 2, 3, 7}, a.Union(b).Elements())
+	assert.Equal(t, []int{2}, a.Intersect(b).Elements())
+	assert.Equal(t, []int{0, 7}, a.Difference(b).Elements())
+	assert.Equal(t, []int{0, 3, 7}, a.SymmetricDifference(b).Elements())
+	assert.Equal(t, []int{1, 3, 4, 5, 6}, a.Complement().Elements())
+	assert.True(t, a.Has(7))
+	assert.False(t, a.Has(8))
+	assert.Equal(t, int8(-128+5), a.Bits())
+	assert.True(t, a.Intersect(b).IsSubset(b))
+	assert.False(t, a.IsSubset(b))
+	assert.True(t, a.Remove(0).Remove(2).Remove(7).IsEmpty())
+	assert.Equal(t, 3, a.Len())
+	assert.Equal(t, 8, a.Cap())
+	assert.Panics(t, func() { a.Add(8) })
+	assert.Equal(t, BitsetOf(uint64(1<<63)), NewBitset[uint64](63))
+}
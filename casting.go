@@ -2,7 +2,10 @@ package oprs
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
+	"strconv"
 	"unicode/utf8"
 
 	"github.com/kendfss/rules"
@@ -395,3 +398,61 @@ func Chars[char byte | rune](w string) (out []char) {
 	}
 	return
 }
+
+// TryToDecimal converts a real number to a Decimal
+// floats become the shortest decimal that rounds back to them, so 0.1 becomes "0.1",
+// and NaN or ±Inf are reported as errors
+func TryToDecimal[T rules.Real](arg T) (Decimal, error) {
+	k := kindOf[T]()
+	switch {
+	case k.float:
+		f := float64(arg)
+		if math.IsNaN(f) {
+			return Decimal{}, fmt.Errorf("oprs.cast: %v to Decimal: %w", arg, ErrNaN)
+		}
+		if math.IsInf(f, 0) {
+			return Decimal{}, fmt.Errorf("oprs.cast: %v to Decimal: %w", arg, ErrOverflow)
+		}
+		return TryParseDecimal(strconv.FormatFloat(f, 'e', -1, k.bits))
+	case k.signed:
+		return Decimal{big.NewInt(int64(arg)), 0}, nil
+	}
+	return Decimal{new(big.Int).SetUint64(uint64(arg)), 0}, nil
+}
+
+// ToDecimal converts a real number to a Decimal
+// under the hood, it's a panicky-wrapper on TryToDecimal
+func ToDecimal[T rules.Real](arg T) Decimal {
+	return Must(TryToDecimal[T])(arg)
+}
+
+// TryCastDecimal converts a Decimal to a real number type, with the checks of TryCast
+// float targets get the nearest value, and integer targets report fractions as ErrTruncated
+func TryCastDecimal[O rules.Real](d Decimal) (O, error) {
+	// floats are rounded once, straight from the exact value, as rounding
+	// to float64 first could land a float32 on the wrong side of a tie
+	if k := kindOf[O](); k.float {
+		var f float64
+		if k.bits == 32 {
+			f32, _ := d.Rat().Float32()
+			f = float64(f32)
+		} else {
+			f, _ = d.Float64()
+		}
+		if math.IsInf(f, 0) {
+			return 0, fmt.Errorf("oprs.cast: %v to %T: %w", d, *new(O), ErrOverflow)
+		}
+		return O(f), nil
+	}
+	r := d.Rat()
+	if !r.IsInt() {
+		return 0, fmt.Errorf("oprs.cast: %v to %T: %w", d, *new(O), ErrTruncated)
+	}
+	switch n := r.Num(); {
+	case n.IsInt64():
+		return TryCast[O](n.Int64())
+	case n.IsUint64():
+		return TryCast[O](n.Uint64())
+	}
+	return 0, fmt.Errorf("oprs.cast: %v to %T: %w", d, *new(O), ErrOverflow)
+}
@@ -1,6 +1,7 @@
 package oprs
 
 import (
+	"math"
 	"testing"
 
 	"github.com/kendfss/oprs/internal/tools"
@@ -41,3 +42,44 @@ func TestBeforeAfter(t *testing.T) {
 		assert.Equal(t, want, have, "#%d\n\twant %d, have %d", i, want, have)
 	}
 }
+
+func TestDecimalCast(t *testing.T) {
+	assert.Equal(t, "0.1", ToDecimal(0.1).String())
+	assert.Equal(t, "0.1", ToDecimal(float32(0.1)).String())
+	assert.Equal(t, "-1500", ToDecimal(-1.5e3).String())
+	assert.Equal(t, "-128", ToDecimal(int8(-128)).String())
+	assert.Equal(t, "18446744073709551615", ToDecimal(uint64(math.MaxUint64)).String())
+	_, err := TryToDecimal(math.NaN())
+	assert.ErrorIs(t, err, ErrNaN)
+	_, err = TryToDecimal(math.Inf(-1))
+	assert.ErrorIs(t, err, ErrOverflow)
+	assert.Panics(t, func() { ToDecimal(math.Inf(1)) })
+
+	i, err := TryCastDecimal[int8](ParseDecimal("-12.00"))
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-12), i)
+	u, err := TryCastDecimal[uint64](ParseDecimal("18446744073709551615"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u)
+	f, err := TryCastDecimal[float64](ParseDecimal("19.99"))
+	assert.NoError(t, err)
+	assert.Equal(t, 19.99, f)
+	// 1 + 2⁻²⁴ + 2⁻⁶⁰ is just above the tie between float32 1 and 1+2⁻²³, but
+	// rounds to the tie in float64, which would then round to even
+	f32, err := TryCastDecimal[float32](ParseDecimal("1.000000059604644776257986737988403547205962240695953369140625"))
+	assert.NoError(t, err)
+	assert.Equal(t, math.Nextafter32(1, 2), f32)
+
+	_, err = TryCastDecimal[int](ParseDecimal("1.5"))
+	assert.ErrorIs(t, err, ErrTruncated)
+	_, err = TryCastDecimal[uint8](ParseDecimal("-1"))
+	assert.ErrorIs(t, err, ErrSign)
+	_, err = TryCastDecimal[int8](ParseDecimal("128"))
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = TryCastDecimal[uint64](ParseDecimal("1e20"))
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = TryCastDecimal[float32](ParseDecimal("1e39"))
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = TryCastDecimal[float64](ParseDecimal("1e400"))
+	assert.ErrorIs(t, err, ErrOverflow)
+}
@@ -0,0 +1,304 @@
+package oprs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/kendfss/oprs/internal/tools"
+	"github.com/kendfss/rules"
+)
+
+var (
+	// ErrOverflow is reported when a value falls outside the range of the target type
+	ErrOverflow = errors.New("value out of range")
+	// ErrSign is reported when a negative value is cast to an unsigned type
+	ErrSign = errors.New("sign lost")
+	// ErrNaN is reported when NaN is cast to a type that cannot represent it
+	ErrNaN = errors.New("not a number")
+	// ErrTruncated is reported when a fractional value is cast to an integer type
+	ErrTruncated = errors.New("fraction truncated")
+)
+
+// numKind describes the representation of a numeric type
+type numKind struct {
+	float, complex, signed bool
+	bits                   int
+}
+
+// kindOf inspects the representation of the given numeric type
+func kindOf[T rules.Number]() numKind {
+	t := reflect.TypeOf(*new(T))
+	k := numKind{bits: t.Bits()}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		k.signed = true
+	case reflect.Float32, reflect.Float64:
+		k.float, k.signed = true, true
+	case reflect.Complex64, reflect.Complex128:
+		k.complex, k.signed = true, true
+		k.bits /= 2
+	}
+	return k
+}
+
+// ceilOf returns hi+1, the exclusive upper bound of an integer type whose largest value is hi
+// hi+1 is a power of two, so it is exact in float64: when hi itself is not, float64(hi)
+// has already rounded up to it, and adding 1 leaves it there
+func ceilOf[O rules.Real](hi O) float64 {
+	return float64(hi) + 1
+}
+
+func castErr[O, I rules.Real](arg I, err error) error {
+	return fmt.Errorf("oprs.cast: %v to %T: %w", arg, *new(O), err)
+}
+
+// TryCast converts between real number types and reports an error instead of
+// silently overflowing, losing a sign, swallowing NaN/Inf or truncating a fraction
+func TryCast[O, I rules.Real](arg I) (O, error) {
+	in, out := kindOf[I](), kindOf[O]()
+	switch {
+	case in.float:
+		f := float64(arg)
+		switch {
+		case math.IsNaN(f) && !out.float:
+			return 0, castErr[O](arg, ErrNaN)
+		case math.IsInf(f, 0) && !out.float:
+			return 0, castErr[O](arg, ErrOverflow)
+		case out.float:
+			if out.bits == 32 && !math.IsInf(f, 0) && math.Abs(f) > math.MaxFloat32 {
+				return 0, castErr[O](arg, ErrOverflow)
+			}
+			return O(arg), nil
+		case f != math.Trunc(f):
+			return 0, castErr[O](arg, ErrTruncated)
+		case f < 0 && !out.signed:
+			return 0, castErr[O](arg, ErrSign)
+		}
+		if lo, hi := tools.IntBounds[O](); f < float64(lo) || f >= ceilOf(hi) {
+			return 0, castErr[O](arg, ErrOverflow)
+		}
+	case out.float:
+	case in.signed && arg < 0:
+		if !out.signed {
+			return 0, castErr[O](arg, ErrSign)
+		}
+		if lo, _ := tools.IntBounds[O](); int64(arg) < int64(lo) {
+			return 0, castErr[O](arg, ErrOverflow)
+		}
+	default:
+		if _, hi := tools.IntBounds[O](); uint64(arg) > uint64(hi) {
+			return 0, castErr[O](arg, ErrOverflow)
+		}
+	}
+	return O(arg), nil
+}
+
+// ClampCast converts between real number types, saturating at the bounds of the
+// target type instead of wrapping around
+// NaN becomes zero for integer targets and fractions are truncated toward zero
+func ClampCast[O, I rules.Real](arg I) O {
+	in, out := kindOf[I](), kindOf[O]()
+	switch {
+	case in.float:
+		f := float64(arg)
+		if out.float {
+			if out.bits == 32 && !math.IsInf(f, 0) && math.Abs(f) > math.MaxFloat32 {
+				return O(math.Copysign(math.MaxFloat32, f))
+			}
+			return O(arg)
+		}
+		if math.IsNaN(f) {
+			return 0
+		}
+		lo, hi := tools.IntBounds[O]()
+		if f < float64(lo) {
+			return lo
+		} else if f >= ceilOf(hi) {
+			return hi
+		}
+	case out.float:
+	case in.signed && arg < 0:
+		if lo, _ := tools.IntBounds[O](); int64(arg) < int64(lo) {
+			return lo
+		}
+	default:
+		if _, hi := tools.IntBounds[O](); uint64(arg) > uint64(hi) {
+			return hi
+		}
+	}
+	return O(arg)
+}
+
+// TryToUint8 casts the argument to a uint8 value, or reports why it cannot
+func TryToUint8[T rules.Real](arg T) (uint8, error) {
+	return TryCast[uint8](arg)
+}
+
+// TryToUint16 casts the argument to a uint16 value, or reports why it cannot
+func TryToUint16[T rules.Real](arg T) (uint16, error) {
+	return TryCast[uint16](arg)
+}
+
+// TryToUint32 casts the argument to a uint32 value, or reports why it cannot
+func TryToUint32[T rules.Real](arg T) (uint32, error) {
+	return TryCast[uint32](arg)
+}
+
+// TryToUint64 casts the argument to a uint64 value, or reports why it cannot
+func TryToUint64[T rules.Real](arg T) (uint64, error) {
+	return TryCast[uint64](arg)
+}
+
+// TryToInt8 casts the argument to a int8 value, or reports why it cannot
+func TryToInt8[T rules.Real](arg T) (int8, error) {
+	return TryCast[int8](arg)
+}
+
+// TryToInt16 casts the argument to a int16 value, or reports why it cannot
+func TryToInt16[T rules.Real](arg T) (int16, error) {
+	return TryCast[int16](arg)
+}
+
+// TryToInt32 casts the argument to a int32 value, or reports why it cannot
+func TryToInt32[T rules.Real](arg T) (int32, error) {
+	return TryCast[int32](arg)
+}
+
+// TryToInt64 casts the argument to a int64 value, or reports why it cannot
+func TryToInt64[T rules.Real](arg T) (int64, error) {
+	return TryCast[int64](arg)
+}
+
+// TryToFloat32 casts the argument to a float32 value, or reports why it cannot
+func TryToFloat32[T rules.Real](arg T) (float32, error) {
+	return TryCast[float32](arg)
+}
+
+// TryToFloat64 casts the argument to a float64 value, or reports why it cannot
+func TryToFloat64[T rules.Real](arg T) (float64, error) {
+	return TryCast[float64](arg)
+}
+
+// TryToComplex64 casts the argument to a complex64 value, or reports why it cannot
+func TryToComplex64[T rules.Real](arg T) (complex64, error) {
+	f, err := TryCast[float32](arg)
+	return complex(f, 0), err
+}
+
+// TryToComplex128 casts the argument to a complex128 value, or reports why it cannot
+func TryToComplex128[T rules.Real](arg T) (complex128, error) {
+	f, err := TryCast[float64](arg)
+	return complex(f, 0), err
+}
+
+// TryToInt casts the argument to a int value, or reports why it cannot
+func TryToInt[T rules.Real](arg T) (int, error) {
+	return TryCast[int](arg)
+}
+
+// TryToUint casts the argument to a uint value, or reports why it cannot
+func TryToUint[T rules.Real](arg T) (uint, error) {
+	return TryCast[uint](arg)
+}
+
+// TryToUintptr casts the argument to a uintptr value, or reports why it cannot
+func TryToUintptr[T rules.Real](arg T) (uintptr, error) {
+	return TryCast[uintptr](arg)
+}
+
+// TryToByte casts the argument to a byte value, or reports why it cannot
+func TryToByte[T rules.Real](arg T) (byte, error) {
+	return TryCast[byte](arg)
+}
+
+// TryToRune casts the argument to a rune value, or reports why it cannot
+func TryToRune[T rules.Real](arg T) (rune, error) {
+	return TryCast[rune](arg)
+}
+
+// ClampToUint8 casts the argument to a uint8 value, saturating on overflow
+func ClampToUint8[T rules.Real](arg T) uint8 {
+	return ClampCast[uint8](arg)
+}
+
+// ClampToUint16 casts the argument to a uint16 value, saturating on overflow
+func ClampToUint16[T rules.Real](arg T) uint16 {
+	return ClampCast[uint16](arg)
+}
+
+// ClampToUint32 casts the argument to a uint32 value, saturating on overflow
+func ClampToUint32[T rules.Real](arg T) uint32 {
+	return ClampCast[uint32](arg)
+}
+
+// ClampToUint64 casts the argument to a uint64 value, saturating on overflow
+func ClampToUint64[T rules.Real](arg T) uint64 {
+	return ClampCast[uint64](arg)
+}
+
+// ClampToInt8 casts the argument to a int8 value, saturating on overflow
+func ClampToInt8[T rules.Real](arg T) int8 {
+	return ClampCast[int8](arg)
+}
+
+// ClampToInt16 casts the argument to a int16 value, saturating on overflow
+func ClampToInt16[T rules.Real](arg T) int16 {
+	return ClampCast[int16](arg)
+}
+
+// ClampToInt32 casts the argument to a int32 value, saturating on overflow
+func ClampToInt32[T rules.Real](arg T) int32 {
+	return ClampCast[int32](arg)
+}
+
+// ClampToInt64 casts the argument to a int64 value, saturating on overflow
+func ClampToInt64[T rules.Real](arg T) int64 {
+	return ClampCast[int64](arg)
+}
+
+// ClampToFloat32 casts the argument to a float32 value, saturating on overflow
+func ClampToFloat32[T rules.Real](arg T) float32 {
+	return ClampCast[float32](arg)
+}
+
+// ClampToFloat64 casts the argument to a float64 value, saturating on overflow
+func ClampToFloat64[T rules.Real](arg T) float64 {
+	return ClampCast[float64](arg)
+}
+
+// ClampToComplex64 casts the argument to a complex64 value, saturating on overflow
+func ClampToComplex64[T rules.Real](arg T) complex64 {
+	return complex(ClampCast[float32](arg), 0)
+}
+
+// ClampToComplex128 casts the argument to a complex128 value, saturating on overflow
+func ClampToComplex128[T rules.Real](arg T) complex128 {
+	return complex(ClampCast[float64](arg), 0)
+}
+
+// ClampToInt casts the argument to a int value, saturating on overflow
+func ClampToInt[T rules.Real](arg T) int {
+	return ClampCast[int](arg)
+}
+
+// ClampToUint casts the argument to a uint value, saturating on overflow
+func ClampToUint[T rules.Real](arg T) uint {
+	return ClampCast[uint](arg)
+}
+
+// ClampToUintptr casts the argument to a uintptr value, saturating on overflow
+func ClampToUintptr[T rules.Real](arg T) uintptr {
+	return ClampCast[uintptr](arg)
+}
+
+// ClampToByte casts the argument to a byte value, saturating on overflow
+func ClampToByte[T rules.Real](arg T) byte {
+	return ClampCast[byte](arg)
+}
+
+// ClampToRune casts the argument to a rune value, saturating on overflow
+func ClampToRune[T rules.Real](arg T) rune {
+	return ClampCast[rune](arg)
+}
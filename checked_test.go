@@ -0,0 +1,53 @@
+package oprs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryCast(t *testing.T) {
+	_, err := TryToInt8(200)
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = TryToUint16(-1)
+	assert.ErrorIs(t, err, ErrSign)
+	_, err = TryToInt64(math.NaN())
+	assert.ErrorIs(t, err, ErrNaN)
+	_, err = TryToInt32(math.Inf(1))
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = TryToInt(2.5)
+	assert.ErrorIs(t, err, ErrTruncated)
+	_, err = TryToFloat32(1e39)
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = TryToInt64(float64(1 << 63))
+	assert.ErrorIs(t, err, ErrOverflow)
+	_, err = TryToUint32(uint64(1 << 32))
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	i8, err := TryToInt8(-128)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-128), i8)
+	u64, err := TryToUint64(uint64(math.MaxUint64))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u64)
+	i64, err := TryToInt64(-float64(1 << 63))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MinInt64), i64)
+	c, err := TryToComplex64(3)
+	assert.NoError(t, err)
+	assert.Equal(t, complex64(3), c)
+}
+
+func TestClampCast(t *testing.T) {
+	assert.Equal(t, int8(127), ClampToInt8(1000))
+	assert.Equal(t, int8(-128), ClampToInt8(-1000))
+	assert.Equal(t, uint8(0), ClampToUint8(-5))
+	assert.Equal(t, uint8(255), ClampToUint8(math.Inf(1)))
+	assert.Equal(t, int32(0), ClampToInt32(math.NaN()))
+	assert.Equal(t, int64(math.MaxInt64), ClampToInt64(uint64(math.MaxUint64)))
+	assert.Equal(t, uint64(math.MaxUint64), ClampToUint64(1e30))
+	assert.Equal(t, int16(-3), ClampToInt16(-3.9))
+	assert.Equal(t, float32(math.MaxFloat32), ClampToFloat32(1e300))
+	assert.Equal(t, uint(0), ClampToUint(math.MinInt64))
+}
@@ -0,0 +1,189 @@
+package oprs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// ErrUncloneable is reported by DeepClone for values it cannot copy, such as channels and funcs
+var ErrUncloneable = errors.New("cannot be cloned")
+
+var (
+	clonersMu sync.RWMutex
+	cloners   = map[reflect.Type]func(reflect.Value) reflect.Value{}
+)
+
+// RegisterCloner tells DeepClone how to copy values of the given type
+// It is meant for types whose copies need more than their fields, or less
+// It replaces any cloner previously registered for the type
+func RegisterCloner[T any](fn func(T) T) {
+	clonersMu.Lock()
+	defer clonersMu.Unlock()
+	cloners[reflect.TypeFor[T]()] = func(v reflect.Value) reflect.Value {
+		out := reflect.New(v.Type()).Elem()
+		out.Set(reflect.ValueOf(fn(v.Interface().(T))))
+		return out
+	}
+}
+
+func init() {
+	RegisterCloner(func(x big.Int) (y big.Int) {
+		y.Set(&x)
+		return y
+	})
+	RegisterCloner(func(x big.Rat) (y big.Rat) {
+		y.Set(&x)
+		return y
+	})
+	RegisterCloner(func(x big.Float) (y big.Float) {
+		y.Copy(&x)
+		return y
+	})
+	RegisterCloner(Returner[time.Time])
+}
+
+// DeepClone returns a copy of the given value that shares no memory with it
+// Pointers, slices, maps, structs (including their unexported fields), arrays and
+// interfaces are copied recursively, and shared or cyclic references are preserved
+// It reports an ErrUncloneable for non-nil channels, funcs and unsafe pointers
+func DeepClone[T any](arg T) (T, error) {
+	out := new(T)
+	c := cloner{seen: map[visit]reflect.Value{}}
+	err := c.copy(reflect.ValueOf(out).Elem(), reflect.ValueOf(&arg).Elem(), "arg")
+	return *out, err
+}
+
+// visit identifies a reference that has already been copied
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+type cloner struct {
+	seen map[visit]reflect.Value
+}
+
+// copy deep-copies src into dst, which must be settable
+// src must be addressable if it is, or contains, a struct
+func (c cloner) copy(dst, src reflect.Value, path string) error {
+	t := src.Type()
+	clonersMu.RLock()
+	fn, ok := cloners[t]
+	clonersMu.RUnlock()
+	if ok {
+		dst.Set(fn(src))
+		return nil
+	}
+
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return nil
+		}
+		return c.shared(dst, visit{src.Pointer(), t, 0}, func() reflect.Value {
+			return reflect.New(t.Elem())
+		}, func(p reflect.Value) error {
+			return c.copy(p.Elem(), src.Elem(), "(*"+path+")")
+		})
+	case reflect.Slice:
+		if src.IsNil() {
+			return nil
+		}
+		return c.shared(dst, visit{src.Pointer(), t, src.Len()}, func() reflect.Value {
+			return reflect.MakeSlice(t, src.Len(), src.Cap())
+		}, func(s reflect.Value) error {
+			for i := 0; i < src.Len(); i++ {
+				if err := c.copy(s.Index(i), src.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case reflect.Map:
+		if src.IsNil() {
+			return nil
+		}
+		return c.shared(dst, visit{src.Pointer(), t, 0}, func() reflect.Value {
+			return reflect.MakeMapWithSize(t, src.Len())
+		}, func(m reflect.Value) error {
+			for it := src.MapRange(); it.Next(); {
+				key, val := reflect.New(t.Key()).Elem(), reflect.New(t.Elem()).Elem()
+				if err := c.copy(key, addressable(it.Key()), path+"{key}"); err != nil {
+					return err
+				}
+				if err := c.copy(val, addressable(it.Value()), fmt.Sprintf("%s[%v]", path, it.Key())); err != nil {
+					return err
+				}
+				m.SetMapIndex(key, val)
+			}
+			return nil
+		})
+	case reflect.Array:
+		src = addressable(src)
+		for i := 0; i < src.Len(); i++ {
+			if err := c.copy(dst.Index(i), src.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		src = addressable(src)
+		for i := 0; i < src.NumField(); i++ {
+			if err := c.copy(exposed(dst.Field(i)), exposed(src.Field(i)), path+"."+t.Field(i).Name); err != nil {
+				return err
+			}
+		}
+	case reflect.Interface:
+		if src.IsNil() {
+			return nil
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		if err := c.copy(elem, addressable(src.Elem()), path); err != nil {
+			return err
+		}
+		dst.Set(elem)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if src.IsNil() {
+			return nil
+		}
+		return fmt.Errorf("oprs.clone: %s (%s): %w", path, t, ErrUncloneable)
+	default:
+		dst.Set(src)
+	}
+	return nil
+}
+
+// shared copies a reference type once, no matter how many times it is reached
+func (c cloner) shared(dst reflect.Value, key visit, alloc func() reflect.Value, fill func(reflect.Value) error) error {
+	if v, ok := c.seen[key]; ok {
+		dst.Set(v)
+		return nil
+	}
+	v := alloc()
+	c.seen[key] = v
+	dst.Set(v)
+	return fill(v)
+}
+
+// addressable returns an addressable copy of v, unless it already is
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	out := reflect.New(v.Type()).Elem()
+	out.Set(v)
+	return out
+}
+
+// exposed lifts the read-only restriction placed on unexported fields
+func exposed(v reflect.Value) reflect.Value {
+	if v.CanSet() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
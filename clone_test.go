@@ -0,0 +1,59 @@
+package oprs
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type node struct {
+	Val   int
+	next  *node
+	tags  []string
+	attrs map[string]any
+}
+
+func TestDeepClone(t *testing.T) {
+	a := &node{Val: 1, tags: []string{"x"}, attrs: map[string]any{"n": []int{1}}}
+	b := &node{Val: 2, next: a, tags: a.tags}
+	a.next = b
+
+	c, err := DeepClone(a)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, c.Val)
+	assert.Equal(t, 2, c.next.Val)
+	assert.True(t, c.next.next == c, "cycles should be preserved")
+	assert.True(t, c != a && c.next != b)
+
+	c.tags[0] = "y"
+	assert.Equal(t, "y", c.next.tags[0], "aliased slices should stay aliased")
+	assert.Equal(t, "x", a.tags[0])
+	c.attrs["n"].([]int)[0] = 2
+	assert.Equal(t, 1, a.attrs["n"].([]int)[0])
+
+	arr, err := DeepClone([2]*big.Int{big.NewInt(3), nil})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", arr[0].String())
+
+	now := time.Now()
+	clonedNow, err := DeepClone(now)
+	assert.NoError(t, err)
+	assert.True(t, now == clonedNow, "time.Time should be copied by value")
+}
+
+func TestDeepCloneErrors(t *testing.T) {
+	_, err := DeepClone(map[string]any{"f": func() {}})
+	assert.ErrorIs(t, err, ErrUncloneable)
+	assert.Contains(t, err.Error(), "arg[f]")
+
+	_, err = DeepClone(struct{ ch chan int }{})
+	assert.NoError(t, err, "nil channels can be copied")
+
+	type counter struct{ ch chan int }
+	RegisterCloner(func(c counter) counter { return counter{make(chan int, cap(c.ch))} })
+	out, err := DeepClone([]counter{{make(chan int, 3)}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, cap(out[0].ch))
+}
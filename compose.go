@@ -0,0 +1,121 @@
+package oprs
+
+// Compose concatenates two unary operators, right to left
+// Compose(f, g)(x) == f(g(x)), see Pipe
+func Compose[A, B, C any](two func(B) C, one func(A) B) func(A) C {
+	return func(arg A) C {
+		return two(one(arg))
+	}
+}
+
+// Pipe3 concatenates three unary operators, left to right
+func Pipe3[A, B, C, D any](one func(A) B, two func(B) C, three func(C) D) func(A) D {
+	return Pipe(Pipe(one, two), three)
+}
+
+// Pipe4 concatenates four unary operators, left to right
+func Pipe4[A, B, C, D, E any](one func(A) B, two func(B) C, three func(C) D, four func(D) E) func(A) E {
+	return Pipe(Pipe3(one, two, three), four)
+}
+
+// Pipe5 concatenates five unary operators, left to right
+func Pipe5[A, B, C, D, E, F any](one func(A) B, two func(B) C, three func(C) D, four func(D) E, five func(E) F) func(A) F {
+	return Pipe(Pipe4(one, two, three, four), five)
+}
+
+// Pipe6 concatenates six unary operators, left to right
+func Pipe6[A, B, C, D, E, F, G any](one func(A) B, two func(B) C, three func(C) D, four func(D) E, five func(E) F, six func(F) G) func(A) G {
+	return Pipe(Pipe5(one, two, three, four, five), six)
+}
+
+// Pipe7 concatenates seven unary operators, left to right
+func Pipe7[A, B, C, D, E, F, G, H any](one func(A) B, two func(B) C, three func(C) D, four func(D) E, five func(E) F, six func(F) G, seven func(G) H) func(A) H {
+	return Pipe(Pipe6(one, two, three, four, five, six), seven)
+}
+
+// Pipe8 concatenates eight unary operators, left to right
+func Pipe8[A, B, C, D, E, F, G, H, I any](one func(A) B, two func(B) C, three func(C) D, four func(D) E, five func(E) F, six func(F) G, seven func(G) H, eight func(H) I) func(A) I {
+	return Pipe(Pipe7(one, two, three, four, five, six, seven), eight)
+}
+
+// Compose3 concatenates three unary operators, right to left
+func Compose3[A, B, C, D any](three func(C) D, two func(B) C, one func(A) B) func(A) D {
+	return Pipe3(one, two, three)
+}
+
+// Compose4 concatenates four unary operators, right to left
+func Compose4[A, B, C, D, E any](four func(D) E, three func(C) D, two func(B) C, one func(A) B) func(A) E {
+	return Pipe4(one, two, three, four)
+}
+
+// Compose5 concatenates five unary operators, right to left
+func Compose5[A, B, C, D, E, F any](five func(E) F, four func(D) E, three func(C) D, two func(B) C, one func(A) B) func(A) F {
+	return Pipe5(one, two, three, four, five)
+}
+
+// Compose6 concatenates six unary operators, right to left
+func Compose6[A, B, C, D, E, F, G any](six func(F) G, five func(E) F, four func(D) E, three func(C) D, two func(B) C, one func(A) B) func(A) G {
+	return Pipe6(one, two, three, four, five, six)
+}
+
+// Compose7 concatenates seven unary operators, right to left
+func Compose7[A, B, C, D, E, F, G, H any](seven func(G) H, six func(F) G, five func(E) F, four func(D) E, three func(C) D, two func(B) C, one func(A) B) func(A) H {
+	return Pipe7(one, two, three, four, five, six, seven)
+}
+
+// Compose8 concatenates eight unary operators, right to left
+func Compose8[A, B, C, D, E, F, G, H, I any](eight func(H) I, seven func(G) H, six func(F) G, five func(E) F, four func(D) E, three func(C) D, two func(B) C, one func(A) B) func(A) I {
+	return Pipe8(one, two, three, four, five, six, seven, eight)
+}
+
+// PipeErr concatenates two fallible unary operators, left to right
+func PipeErr[A, B, C any](one func(A) (B, error), two func(B) (C, error)) func(A) (C, error) {
+	return func(arg A) (C, error) {
+		b, err := one(arg)
+		if err != nil {
+			return *new(C), err
+		}
+		return two(b)
+	}
+}
+
+// PipeErr3 concatenates three fallible unary operators, left to right
+func PipeErr3[A, B, C, D any](one func(A) (B, error), two func(B) (C, error), three func(C) (D, error)) func(A) (D, error) {
+	return PipeErr(PipeErr(one, two), three)
+}
+
+// PipeErr4 concatenates four fallible unary operators, left to right
+func PipeErr4[A, B, C, D, E any](one func(A) (B, error), two func(B) (C, error), three func(C) (D, error), four func(D) (E, error)) func(A) (E, error) {
+	return PipeErr(PipeErr3(one, two, three), four)
+}
+
+// PipeErr5 concatenates five fallible unary operators, left to right
+func PipeErr5[A, B, C, D, E, F any](one func(A) (B, error), two func(B) (C, error), three func(C) (D, error), four func(D) (E, error), five func(E) (F, error)) func(A) (F, error) {
+	return PipeErr(PipeErr4(one, two, three, four), five)
+}
+
+// PipeErr6 concatenates six fallible unary operators, left to right
+func PipeErr6[A, B, C, D, E, F, G any](one func(A) (B, error), two func(B) (C, error), three func(C) (D, error), four func(D) (E, error), five func(E) (F, error), six func(F) (G, error)) func(A) (G, error) {
+	return PipeErr(PipeErr5(one, two, three, four, five), six)
+}
+
+// PipeErr7 concatenates seven fallible unary operators, left to right
+func PipeErr7[A, B, C, D, E, F, G, H any](one func(A) (B, error), two func(B) (C, error), three func(C) (D, error), four func(D) (E, error), five func(E) (F, error), six func(F) (G, error), seven func(G) (H, error)) func(A) (H, error) {
+	return PipeErr(PipeErr6(one, two, three, four, five, six), seven)
+}
+
+// PipeErr8 concatenates eight fallible unary operators, left to right
+func PipeErr8[A, B, C, D, E, F, G, H, I any](one func(A) (B, error), two func(B) (C, error), three func(C) (D, error), four func(D) (E, error), five func(E) (F, error), six func(F) (G, error), seven func(G) (H, error), eight func(H) (I, error)) func(A) (I, error) {
+	return PipeErr(PipeErr7(one, two, three, four, five, six, seven), eight)
+}
+
+// Chain concatenates any number of operators over a shared type, left to right
+// If no argument is given, the returned operator is the identity
+func Chain[T any](ops ...Op[T]) Op[T] {
+	return func(arg T) T {
+		for _, op := range ops {
+			arg = op(arg)
+		}
+		return arg
+	}
+}
@@ -0,0 +1,44 @@
+package oprs
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeN(t *testing.T) {
+	inc := Bind(Add[int], 1)
+	dbl := Bind(Mul[int], 2)
+	assert.Equal(t, 6, Pipe3(inc, dbl, Returner[int])(2))
+	assert.Equal(t, 5, Compose3(inc, dbl, Returner[int])(2))
+	assert.Equal(t, "10", Pipe8(inc, dbl, inc, dbl, inc, dbl, Bind(Sub[int], 4), strconv.Itoa)(0))
+	assert.Equal(t, Pipe4(inc, dbl, inc, dbl)(3), Compose4(dbl, inc, dbl, inc)(3))
+	assert.Equal(t, 10, Chain[int](inc, dbl, inc, dbl)(1))
+	assert.Equal(t, 7, Chain[int]()(7))
+}
+
+func TestPipeErr(t *testing.T) {
+	calls := 0
+	half := func(i int) (int, error) {
+		calls++
+		if IsOdd(i) {
+			return 0, errors.New("odd")
+		}
+		return i / 2, nil
+	}
+	fn := PipeErr4(strconv.Atoi, half, half, half)
+
+	out, err := fn("24")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, out)
+
+	calls = 0
+	_, err = fn("12")
+	assert.EqualError(t, err, "odd")
+	assert.Equal(t, 3, calls)
+
+	_, err = PipeErr(strconv.Atoi, half)("x")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
@@ -0,0 +1,273 @@
+package oprs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrDivideByZero is reported when a Decimal is divided by zero
+	ErrDivideByZero = errors.New("division by zero")
+	// ErrNegativeWeight is reported when Allocate is given a negative weight
+	ErrNegativeWeight = errors.New("negative weight")
+)
+
+// Rounding chooses how a Decimal loses digits
+// the zero value is RoundHalfEven
+type Rounding int
+
+const (
+	RoundHalfEven Rounding = iota // to nearest, ties to an even last digit, aka banker's rounding
+	RoundHalfUp                   // to nearest, ties away from zero
+	RoundFloor                    // towards -Inf
+	RoundCeiling                  // towards +Inf
+	RoundTruncate                 // towards zero
+)
+
+// Decimal is an exact, fixed-point decimal number, coef × 10^-scale, for
+// quantities, such as money, that binary floats cannot represent
+// The scale is the number of digits after the point, and is kept by
+// arithmetic, so that "1.50" + "2" is "3.50"
+// The zero value is 0. Decimals are values: methods never modify their
+// receivers or arguments, but they hold pointers, so compare them with Cmp
+type Decimal struct {
+	coef  *big.Int // nil for zero
+	scale int
+}
+
+// NewDecimal returns coef × 10^-scale, eg NewDecimal(1999, 2) is 19.99
+// a negative scale multiplies coef by a power of ten
+func NewDecimal(coef int64, scale int) Decimal {
+	return DecimalFromBig(big.NewInt(coef), scale)
+}
+
+// DecimalFromBig returns coef × 10^-scale, copying coef
+func DecimalFromBig(coef *big.Int, scale int) Decimal {
+	return decimalOf(new(big.Int).Set(coef), scale)
+}
+
+// decimalOf returns coef × 10^-scale, taking ownership of coef
+func decimalOf(coef *big.Int, scale int) Decimal {
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coef, scale}
+}
+
+// pow10 returns 10^n
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) big() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// Coef returns a copy of d's digits as an integer, such that d = Coef × 10^-Scale
+func (d Decimal) Coef() *big.Int {
+	return new(big.Int).Set(d.big())
+}
+
+// Scale returns the number of digits after the point
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 as d is negative, zero or positive
+func (d Decimal) Sign() int {
+	return d.big().Sign()
+}
+
+// IsZero reports whether d is zero, at any scale
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{new(big.Int).Neg(d.big()), d.scale}
+}
+
+// Abs returns |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{new(big.Int).Abs(d.big()), d.scale}
+}
+
+// rescaled returns d's coefficient at a scale no smaller than its own
+func (d Decimal) rescaled(scale int) *big.Int {
+	if scale == d.scale {
+		return d.big()
+	}
+	return new(big.Int).Mul(d.big(), pow10(scale-d.scale))
+}
+
+// Cmp returns -1, 0 or +1 as d is less than, equal to or greater than e
+// scale is ignored, so "1.50" and "1.5" are equal
+func (d Decimal) Cmp(e Decimal) int {
+	scale := max(d.scale, e.scale)
+	return d.rescaled(scale).Cmp(e.rescaled(scale))
+}
+
+// Add returns d + e, exactly, at the larger of their scales
+func (d Decimal) Add(e Decimal) Decimal {
+	scale := max(d.scale, e.scale)
+	return Decimal{new(big.Int).Add(d.rescaled(scale), e.rescaled(scale)), scale}
+}
+
+// Sub returns d - e, exactly, at the larger of their scales
+func (d Decimal) Sub(e Decimal) Decimal {
+	scale := max(d.scale, e.scale)
+	return Decimal{new(big.Int).Sub(d.rescaled(scale), e.rescaled(scale)), scale}
+}
+
+// Mul returns d × e, exactly, at the sum of their scales
+// use Round to bring it back to the scale of a currency
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{new(big.Int).Mul(d.big(), e.big()), d.scale + e.scale}
+}
+
+// Quo returns d ÷ e, rounded to the given scale with the given mode
+// it reports an ErrDivideByZero if e is zero
+func (d Decimal) Quo(e Decimal, scale int, mode Rounding) (Decimal, error) {
+	if e.IsZero() {
+		return Decimal{}, fmt.Errorf("oprs.decimal: %v / %v: %w", d, e, ErrDivideByZero)
+	}
+	// d/e × 10^scale = dc/ec × 10^(scale - d.scale + e.scale)
+	num, den := d.Coef(), e.Coef()
+	if exp := scale - d.scale + e.scale; exp >= 0 {
+		num.Mul(num, pow10(exp))
+	} else {
+		den.Mul(den, pow10(-exp))
+	}
+	return decimalOf(roundQuo(num, den, mode), scale), nil
+}
+
+// Round returns d with the given number of digits after the point, rounding with the given mode
+// a scale larger than d's pads it with zeros, and a negative one rounds to tens, hundreds, etc
+func (d Decimal) Round(scale int, mode Rounding) Decimal {
+	if scale >= d.scale {
+		return Decimal{d.rescaled(scale), scale}
+	}
+	return decimalOf(roundQuo(d.big(), pow10(d.scale-scale), mode), scale)
+}
+
+// roundQuo returns num ÷ den rounded to an integer with the given mode
+func roundQuo(num, den *big.Int, mode Rounding) *big.Int {
+	if den.Sign() < 0 {
+		num, den = new(big.Int).Neg(num), new(big.Int).Neg(den)
+	}
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// r has the sign of num, and q has been truncated towards zero
+	away := false
+	switch mode {
+	case RoundFloor:
+		away = num.Sign() < 0
+	case RoundCeiling:
+		away = num.Sign() > 0
+	case RoundHalfUp, RoundHalfEven:
+		half := new(big.Int).Abs(r)
+		switch half.Lsh(half, 1).Cmp(den) {
+		case 1:
+			away = true
+		case 0:
+			away = mode == RoundHalfUp || q.Bit(0) == 1
+		}
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+	return q
+}
+
+// Allocate splits d into parts proportional to the given weights, at d's scale,
+// such that the parts sum to exactly d
+// Units of the last digit left over by rounding down go to the parts with the largest
+// remainders, earlier parts first, eg $100.00 split 1:1:1 is $33.34, $33.33, $33.33
+// It reports an ErrNegativeWeight for a negative weight, and an ErrDivideByZero if they sum to zero
+func (d Decimal) Allocate(weights ...int) ([]Decimal, error) {
+	total := new(big.Int)
+	for _, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("oprs.decimal: allocate by %v: %w %d", weights, ErrNegativeWeight, w)
+		}
+		total.Add(total, big.NewInt(int64(w)))
+	}
+	if total.Sign() == 0 {
+		return nil, fmt.Errorf("oprs.decimal: allocate by %v: %w", weights, ErrDivideByZero)
+	}
+	abs := new(big.Int).Abs(d.big())
+	shares := make([]*big.Int, len(weights))
+	rems := make([]*big.Int, len(weights))
+	left := new(big.Int).Set(abs)
+	for i, w := range weights {
+		shares[i], rems[i] = new(big.Int).QuoRem(new(big.Int).Mul(abs, big.NewInt(int64(w))), total, new(big.Int))
+		left.Sub(left, shares[i])
+	}
+	// left is less than the number of non-zero remainders, so fits an int
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { return rems[j].Cmp(rems[i]) })
+	for _, i := range order[:left.Int64()] {
+		shares[i].Add(shares[i], big.NewInt(1))
+	}
+	out := make([]Decimal, len(weights))
+	for i, share := range shares {
+		if d.Sign() < 0 {
+			share.Neg(share)
+		}
+		out[i] = Decimal{share, d.scale}
+	}
+	return out, nil
+}
+
+// Rat returns d as an exact *big.Rat
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.big(), pow10(d.scale))
+}
+
+// Float64 returns the nearest float64 to d, and whether it is exact
+func (d Decimal) Float64() (float64, bool) {
+	return d.Rat().Float64()
+}
+
+// String formats d exactly, with all Scale digits after the point, eg "-0.50"
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.big()).String()
+	if d.scale > 0 {
+		if pad := d.scale + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalText implements encoding.TextMarshaler, in the format of String
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, in the format of TryParseDecimal
+func (d *Decimal) UnmarshalText(text []byte) error {
+	out, err := TryParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = out
+	return nil
+}
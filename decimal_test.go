@@ -0,0 +1,132 @@
+package oprs
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimal(t *testing.T) {
+	var zero Decimal
+	assert.Equal(t, "0", zero.String())
+	assert.True(t, zero.IsZero())
+	assert.Equal(t, "19.99", NewDecimal(1999, 2).String())
+	assert.Equal(t, "-0.05", NewDecimal(-5, 2).String())
+	assert.Equal(t, "1200", NewDecimal(12, -2).String())
+	assert.Equal(t, 0, NewDecimal(12, -2).Scale())
+
+	a, b := ParseDecimal("1.50"), ParseDecimal("2")
+	assert.Equal(t, "3.50", a.Add(b).String())
+	assert.Equal(t, "-0.50", a.Sub(b).String())
+	assert.Equal(t, "3.00", a.Mul(b).String())
+	assert.Equal(t, "-1.50", a.Neg().String())
+	assert.Equal(t, "1.50", a.Neg().Abs().String())
+	assert.Equal(t, "1.50", a.String(), "receivers must not be modified")
+	assert.Equal(t, 0, a.Cmp(ParseDecimal("1.5")))
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 1, zero.Cmp(a.Neg()))
+
+	// 0.1 + 0.2 is exact, unlike float64
+	assert.Equal(t, 0, ParseDecimal("0.1").Add(ParseDecimal("0.2")).Cmp(ParseDecimal("0.3")))
+
+	q, err := NewDecimal(1, 0).Quo(NewDecimal(3, 0), 4, RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.3333", q.String())
+	q, err = ParseDecimal("10.00").Quo(ParseDecimal("0.04"), 0, RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "250", q.String())
+	_, err = a.Quo(zero, 2, RoundHalfUp)
+	assert.ErrorIs(t, err, ErrDivideByZero)
+
+	f, exact := ParseDecimal("0.25").Float64()
+	assert.Equal(t, 0.25, f)
+	assert.True(t, exact)
+	_, exact = ParseDecimal("0.1").Float64()
+	assert.False(t, exact)
+	assert.Equal(t, "-3/4", ParseDecimal("-0.750").Rat().RatString())
+	assert.Equal(t, "12345678901234567890.12", DecimalFromBig(ParseBigInt("1234567890123456789012"), 2).String())
+}
+
+func TestDecimalRound(t *testing.T) {
+	modes := []Rounding{RoundHalfEven, RoundHalfUp, RoundFloor, RoundCeiling, RoundTruncate}
+	for _, test := range []struct {
+		in   string
+		want [5]string
+	}{
+		{"2.5", [5]string{"2", "3", "2", "3", "2"}},
+		{"3.5", [5]string{"4", "4", "3", "4", "3"}},
+		{"-2.5", [5]string{"-2", "-3", "-3", "-2", "-2"}},
+		{"2.51", [5]string{"3", "3", "2", "3", "2"}},
+		{"-2.49", [5]string{"-2", "-2", "-3", "-2", "-2"}},
+		{"-0.4", [5]string{"0", "0", "-1", "0", "0"}},
+		{"7", [5]string{"7", "7", "7", "7", "7"}},
+	} {
+		for i, mode := range modes {
+			assert.Equal(t, test.want[i], ParseDecimal(test.in).Round(0, mode).String(), "%s mode %d", test.in, mode)
+		}
+	}
+	assert.Equal(t, "1.2350", ParseDecimal("1.235").Round(4, RoundFloor).String())
+	assert.Equal(t, "1.24", ParseDecimal("1.235").Round(2, RoundHalfEven).String())
+	assert.Equal(t, "1.22", ParseDecimal("1.225").Round(2, RoundHalfEven).String())
+	assert.Equal(t, "1300", ParseDecimal("1250").Round(-2, RoundHalfUp).String())
+
+	for i, mode := range modes {
+		q, err := NewDecimal(-2, 0).Quo(NewDecimal(3, 0), 2, mode)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"-0.67", "-0.67", "-0.67", "-0.66", "-0.66"}[i], q.String())
+	}
+}
+
+func TestDecimalAllocate(t *testing.T) {
+	parts, err := ParseDecimal("100.00").Allocate(1, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"33.34", "33.33", "33.33"}, decimalStrings(parts))
+
+	parts, err = ParseDecimal("-0.05").Allocate(3, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"-0.02", "-0.03"}, decimalStrings(parts))
+
+	parts, err = ParseDecimal("10").Allocate(0, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "3", "7"}, decimalStrings(parts))
+
+	_, err = NewDecimal(1, 0).Allocate(1, -1)
+	assert.ErrorIs(t, err, ErrNegativeWeight)
+	assert.EqualError(t, err, "oprs.decimal: allocate by [1 -1]: negative weight -1")
+	_, err = NewDecimal(1, 0).Allocate(0, 0)
+	assert.ErrorIs(t, err, ErrDivideByZero)
+	_, err = NewDecimal(1, 0).Allocate()
+	assert.ErrorIs(t, err, ErrDivideByZero)
+
+	for i := 0; i < nTests; i++ {
+		amount := NewDecimal(rand.Int63n(1e6)-5e5, 2)
+		weights := make([]int, 1+rand.Intn(nItems))
+		for j := range weights {
+			weights[j] = rand.Intn(nItems)
+		}
+		weights[0]++
+		parts, err := amount.Allocate(weights...)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, amount.Cmp(Sum[Decimal](DecimalArith{})(parts...)), "%v by %v: %v", amount, weights, parts)
+	}
+}
+
+func TestDecimalText(t *testing.T) {
+	var out struct{ Price Decimal }
+	assert.NoError(t, json.Unmarshal([]byte(`{"Price": "12.50"}`), &out))
+	assert.Equal(t, "12.50", out.Price.String())
+	data, err := json.Marshal(out)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Price":"12.50"}`, string(data))
+	assert.Error(t, json.Unmarshal([]byte(`{"Price": "12,50"}`), &out))
+}
+
+func decimalStrings(ds []Decimal) []string {
+	out := make([]string, len(ds))
+	for i, d := range ds {
+		out[i] = d.String()
+	}
+	return out
+}
@@ -0,0 +1,15 @@
+// Package oprs provides generic operators, and the glue to combine them:
+// casts, parsers, predicates, memoization, retries and the like
+//
+// # Composition
+//
+// The fixed-arity Pipe, Compose and PipeErr functions concatenate unary operators,
+// whose types may differ from step to step, without reflection
+//
+//	PipeN(one, ..., n)(x)    == n(...(one(x)))
+//	ComposeN(n, ..., one)(x) == n(...(one(x)))
+//
+// PipeErrN is PipeN over fallible operators: it stops at, and returns, the first error
+// Each arity is built from the one below it, and Chain covers any number of
+// operators that share a type
+package oprs
@@ -0,0 +1,311 @@
+package oprs
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/kendfss/rules"
+)
+
+// Registry names the predicates that an expression compiled by CompilePred may call
+type Registry[T any] map[string]func(T) bool
+
+// IntRegistry returns a registry with the integer predicates from filters.go
+//
+//	even(x), odd(x)
+func IntRegistry[T rules.Integer]() Registry[T] {
+	return Registry[T]{
+		"even": IsEven[T],
+		"odd":  IsOdd[T],
+	}
+}
+
+// ParseError reports where, and why, CompilePred rejected an expression
+type ParseError struct {
+	Pos int // 1-based column
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("oprs.pred: col %d: %s", e.Pos, e.Msg)
+}
+
+// CompilePred compiles a boolean expression over a single variable into a predicate
+// built from the combinators in filters.go and logic.go, eg:
+//
+//	x > 3 && !even(x) || x == 0
+//
+// Expressions may use ||, &&, !, parentheses, true, false, the comparisons
+// == != < <= > >= between the variable and numeric literals, and calls of the form
+// name(x) to predicates in the registry. The variable may have any name, but only one
+// Comparisons bind tightest, then !, then &&, then ||, so !x > 3 means !(x > 3)
+func CompilePred[T rules.Real](src string, reg Registry[T]) (func(T) bool, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &predParser[T]{toks: toks, reg: reg}
+	pred, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+	return pred, nil
+}
+
+// MustCompilePred is like CompilePred, but panics if the expression is invalid
+func MustCompilePred[T rules.Real](src string, reg Registry[T]) func(T) bool {
+	pred, err := CompilePred(src, reg)
+	if err != nil {
+		panic(err)
+	}
+	return pred
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokIdent
+	tokNum
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+// lex splits an expression into tokens
+func lex(src string) (out []token, err error) {
+	rs := []rune(src)
+	for i := 0; i < len(rs); {
+		r, start := rs[i], i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_') {
+				i++
+			}
+			out = append(out, token{tokIdent, string(rs[start:i]), start + 1})
+			continue
+		case unicode.IsDigit(r) || r == '.':
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.' || rs[i] == 'e' || rs[i] == 'E' ||
+				(rs[i] == '-' || rs[i] == '+') && (rs[i-1] == 'e' || rs[i-1] == 'E')) {
+				i++
+			}
+			out = append(out, token{tokNum, string(rs[start:i]), start + 1})
+			continue
+		}
+		if i+1 < len(rs) {
+			switch two := string(rs[i : i+2]); two {
+			case "&&", "||", "==", "!=", "<=", ">=":
+				out = append(out, token{tokOp, two, start + 1})
+				i += 2
+				continue
+			}
+		}
+		switch r {
+		case '!', '<', '>', '(', ')', '-':
+			out = append(out, token{tokOp, string(r), start + 1})
+			i++
+		default:
+			return nil, &ParseError{start + 1, fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return append(out, token{tokEOF, "end of input", len(rs) + 1}), nil
+}
+
+type predParser[T rules.Real] struct {
+	toks []token
+	reg  Registry[T]
+	name string // the variable, once seen
+}
+
+// operand is either the variable or a literal
+type operand[T any] struct {
+	variable bool
+	val      T
+}
+
+func (p *predParser[T]) peek() token {
+	return p.toks[0]
+}
+
+func (p *predParser[T]) next() token {
+	tok := p.toks[0]
+	if tok.kind != tokEOF {
+		p.toks = p.toks[1:]
+	}
+	return tok
+}
+
+func (p *predParser[T]) accept(op string) bool {
+	if tok := p.peek(); tok.kind == tokOp && tok.text == op {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *predParser[T]) errorf(tok token, format string, args ...any) error {
+	return &ParseError{tok.pos, fmt.Sprintf(format, args...)}
+}
+
+func (p *predParser[T]) or() (func(T) bool, error) {
+	return p.chain("||", p.and, Any[T])
+}
+
+func (p *predParser[T]) and() (func(T) bool, error) {
+	return p.chain("&&", p.unary, All[T])
+}
+
+// chain parses a sequence of operands separated by op and joins them with join
+func (p *predParser[T]) chain(op string, operand func() (func(T) bool, error), join func(...func(T) bool) func(T) bool) (func(T) bool, error) {
+	pred, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	preds := []func(T) bool{pred}
+	for p.accept(op) {
+		if pred, err = operand(); err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+	return join(preds...), nil
+}
+
+func (p *predParser[T]) unary() (func(T) bool, error) {
+	if p.accept("!") {
+		pred, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(pred), nil
+	}
+	return p.primary()
+}
+
+func (p *predParser[T]) primary() (func(T) bool, error) {
+	tok := p.peek()
+	switch {
+	case p.accept("("):
+		pred, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, p.errorf(p.peek(), "expected \")\", found %q", p.peek().text)
+		}
+		return pred, nil
+	case tok.kind == tokIdent && (tok.text == "true" || tok.text == "false"):
+		p.next()
+		val := tok.text == "true"
+		return func(T) bool { return val }, nil
+	case tok.kind == tokIdent && len(p.toks) > 1 && p.toks[1].text == "(":
+		return p.call()
+	}
+	return p.comparison()
+}
+
+// call parses name(x) into the registered predicate
+func (p *predParser[T]) call() (func(T) bool, error) {
+	tok := p.next()
+	pred, ok := p.reg[tok.text]
+	if !ok {
+		return nil, p.errorf(tok, "unknown predicate %q", tok.text)
+	}
+	p.next()
+	arg, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	if !arg.variable {
+		return nil, p.errorf(tok, "%s must be called on the variable", tok.text)
+	}
+	if !p.accept(")") {
+		return nil, p.errorf(p.peek(), "expected \")\", found %q", p.peek().text)
+	}
+	return pred, nil
+}
+
+var comparators = map[string]struct{}{"==": {}, "!=": {}, "<": {}, "<=": {}, ">": {}, ">=": {}}
+
+func (p *predParser[T]) comparison() (func(T) bool, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.next()
+	if _, ok := comparators[tok.text]; tok.kind != tokOp || !ok {
+		return nil, p.errorf(tok, "expected comparison, found %q", tok.text)
+	}
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	var cmp func(T, T) bool
+	switch tok.text {
+	case "==":
+		cmp = Eq[T]
+	case "!=":
+		cmp = Ne[T]
+	case "<":
+		cmp = Lt[T]
+	case "<=":
+		cmp = Le[T]
+	case ">":
+		cmp = Gt[T]
+	case ">=":
+		cmp = Ge[T]
+	}
+
+	switch {
+	case left.variable && right.variable:
+		return func(arg T) bool { return cmp(arg, arg) }, nil
+	case left.variable:
+		return Bind(cmp, right.val), nil
+	case right.variable:
+		return Method(left.val, cmp), nil
+	default:
+		val := cmp(left.val, right.val)
+		return func(T) bool { return val }, nil
+	}
+}
+
+func (p *predParser[T]) operand() (operand[T], error) {
+	tok := p.next()
+	switch {
+	case tok.kind == tokIdent:
+		if _, ok := p.reg[tok.text]; ok {
+			return operand[T]{}, p.errorf(tok, "predicate %q must be called, eg %s(x)", tok.text, tok.text)
+		}
+		if p.name == "" {
+			p.name = tok.text
+		} else if p.name != tok.text {
+			return operand[T]{}, p.errorf(tok, "unknown identifier %q, the variable is %q", tok.text, p.name)
+		}
+		return operand[T]{variable: true}, nil
+	case tok.kind == tokOp && tok.text == "-":
+		num := p.next()
+		if num.kind != tokNum || num.pos != tok.pos+1 {
+			return operand[T]{}, p.errorf(tok, "expected number after \"-\"")
+		}
+		tok.text += num.text
+		fallthrough
+	case tok.kind == tokNum:
+		val, err := TryParseFloat[T](tok.text)
+		if err != nil {
+			return operand[T]{}, p.errorf(tok, "invalid %T literal %q: %v", val, tok.text, err)
+		}
+		return operand[T]{val: val}, nil
+	}
+	return operand[T]{}, p.errorf(tok, "expected variable or number, found %q", tok.text)
+}
@@ -0,0 +1,49 @@
+package oprs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompilePred(t *testing.T) {
+	pred, err := CompilePred("x > 3 && !even(x) || x == 0", IntRegistry[int]())
+	assert.NoError(t, err)
+	have := []int{}
+	for i := -2; i < 10; i++ {
+		if pred(i) {
+			have = append(have, i)
+		}
+	}
+	assert.Equal(t, []int{0, 5, 7, 9}, have)
+
+	reg := Registry[float64]{"pos": Bind(Gt[float64], 0)}
+	fpred := MustCompilePred("!(n < -1.5 || 2e1 <= n) && pos(n) || false", reg)
+	assert.True(t, fpred(1))
+	assert.False(t, fpred(-1))
+	assert.False(t, fpred(20))
+	assert.True(t, MustCompilePred[int]("1 < 2", nil)(0))
+}
+
+func TestCompilePredErrors(t *testing.T) {
+	for src, pos := range map[string]int{
+		"x > ":              5,
+		"x > 3 &&":          9,
+		"x > 3 && y < 2":    10,
+		"(x > 3":            7,
+		"prime(x)":          1,
+		"even(3)":           1,
+		"x ? 3":             3,
+		"x > 2.5":           5,
+		"x > 3 x":           7,
+		"even > 3":          1,
+		"x > - 3":           5,
+		"x == 1 || !(x > 1": 18,
+	} {
+		_, err := CompilePred(src, IntRegistry[int]())
+		var perr *ParseError
+		if assert.ErrorAs(t, err, &perr, src) {
+			assert.Equal(t, pos, perr.Pos, "%s: %v", src, err)
+		}
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/kendfss/oprs
 
-go 1.18
+go 1.23
 
 require (
 	github.com/kendfss/rules v1.0.0
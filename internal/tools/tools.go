@@ -1,8 +1,14 @@
 package tools
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"math/rand"
+	"reflect"
+	"runtime"
+	"time"
+	"unsafe"
 
 	"github.com/kendfss/rules"
 )
@@ -93,28 +99,57 @@ func FilterPred[E any](f func(E) bool, args []E) (out []E) {
 	return out
 }
 
-// Uptoch returns an iterator whose content depends on the number of arguments as follows
-// 		# of args 	|| 	behaviour
-//	 		 1	 	|| 	stop
-//		 	 2	 	|| 	start, stop
-//		 	 3	 	|| 	start, stop, step
-//         else 	|| 	error
-func Uptoch[T rules.Real](args ...T) (chan T, error) {
+// Uptoch returns a channel whose content depends on the number of arguments as follows
+//
+//	# of args || behaviour
+//	1         || stop
+//	2         || start, stop
+//	3         || start, stop, step
+//	else      || error
+//
+// The channel is closed once the range is exhausted or the context is done
+func Uptoch[T rules.Real](ctx context.Context, args ...T) (<-chan T, error) {
+	seq, err := UptoSeq(args...)
+	if err != nil {
+		return nil, err
+	}
+	return Chan(ctx, seq), nil
+}
+
+// MustUptoch returns a channel whose behaviour is equivalent to that of Uptoch
+func MustUptoch[T rules.Real](ctx context.Context, args ...T) <-chan T {
+	out, err := Uptoch(ctx, args...)
+	if err == nil {
+		return out
+	}
+	panic(err)
+}
+
+// UptoSeq returns an iterator whose content depends on the number of arguments as follows
+//
+//	# of args || behaviour
+//	1         || stop
+//	2         || start, stop
+//	3         || start, stop, step
+//	else      || error
+//
+// Unlike Uptoch, it does not start a goroutine, so breaking out of it early is free
+func UptoSeq[T rules.Real](args ...T) (iter.Seq[T], error) {
 	switch len(args) {
 	case 1:
-		return Uptoch(0, args[0], 1)
+		return UptoSeq(0, args[0], 1)
 	case 2:
-		return Uptoch(args[0], args[1], 1)
+		return UptoSeq(args[0], args[1], 1)
 	case 3:
-		out := make(chan T)
-		go func() {
+		return func(yield func(T) bool) {
 			start, stop, delta := args[0], args[1], args[2]
 			for stop-delta >= start {
-				out <- start
+				if !yield(start) {
+					return
+				}
 				start += delta
 			}
-		}()
-		return out, nil
+		}, nil
 	case 0:
 		return nil, ErrUptoUnder(args)
 	default:
@@ -122,13 +157,22 @@ func Uptoch[T rules.Real](args ...T) (chan T, error) {
 	}
 }
 
-// MustUptoch returns an iterator whose behaviour is equivalent to that of Range
-func MustUptoch[T rules.Real](args ...T) chan T {
-	out, err := Uptoch(args...)
-	if err == nil {
-		return out
-	}
-	panic(err)
+// Chan feeds an iterator into a channel from a new goroutine
+// The channel is closed, and the goroutine exits, once the iterator is
+// exhausted or the context is done
+func Chan[T any](ctx context.Context, seq iter.Seq[T]) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for e := range seq {
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
 }
 
 // Upto returns an iterator whose content depends on the number of arguments as follows
@@ -175,9 +219,92 @@ func ErrUptoOver[T any](args []T) error {
 	return fmt.Errorf("oprs.upto: too many args (%d). want 1, 2, or 3", len(args))
 }
 
-func Consume[T any](ch chan T) (out []T) {
+// Consume drains a channel into a slice
+func Consume[T any](ch <-chan T) (out []T) {
 	for e := range ch {
 		out = append(out, e)
 	}
 	return
 }
+
+// Complex128 converts any number into a complex128
+func Complex128[N rules.Number](x N) complex128 {
+	v := reflect.ValueOf(x)
+	switch {
+	case v.CanComplex():
+		return v.Complex()
+	case v.CanFloat():
+		return complex(v.Float(), 0)
+	case v.CanInt():
+		return complex(float64(v.Int()), 0)
+	}
+	return complex(float64(v.Uint()), 0)
+}
+
+// IsInt reports whether N is an integer type
+func IsInt[N rules.Number]() bool {
+	var half N = 1
+	half /= 2
+	return half == 0
+}
+
+// IsUnsigned reports whether N is an unsigned integer type
+func IsUnsigned[N rules.Number]() bool {
+	var zero N
+	// -1/2 truncates to 0 in signed types, but is large in unsigned ones
+	return IsInt[N]() && (zero-1)/2 != 0
+}
+
+// IsComplex reports whether N is a complex type
+func IsComplex[N rules.Number]() bool {
+	k := reflect.TypeFor[N]().Kind()
+	return k == reflect.Complex64 || k == reflect.Complex128
+}
+
+// ConjInPlace replaces every element of xs with its complex conjugate
+// it does nothing for real types, and only falls back on reflection for named complex types
+func ConjInPlace[N rules.Number](xs []N) {
+	switch s := any(xs).(type) {
+	case []complex128:
+		for i, c := range s {
+			s[i] = complex(real(c), -imag(c))
+		}
+	case []complex64:
+		for i, c := range s {
+			s[i] = complex(real(c), -imag(c))
+		}
+	default:
+		if !IsComplex[N]() {
+			return
+		}
+		v := reflect.ValueOf(xs)
+		for i := range xs {
+			e := v.Index(i)
+			c := e.Complex()
+			e.SetComplex(complex(real(c), -imag(c)))
+		}
+	}
+}
+
+// Settled waits up to a second for the number of goroutines to fall back to n, for leak tests
+func Settled(n int) bool {
+	for i := 0; i < 1000; i++ {
+		if runtime.NumGoroutine() <= n {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+// IntBounds returns the smallest and largest values of an integer type
+// R is constrained to rules.Real so that callers generic over reals can use it
+// after checking that R is an integer type
+func IntBounds[R rules.Real]() (lo, hi R) {
+	var zero R
+	if ones := zero - 1; ones > 0 {
+		return 0, ones
+	}
+	hi = R(uint64(1)<<(unsafe.Sizeof(zero)*8-1) - 1)
+	return -hi - 1, hi
+}
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUptoch(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	assert.Equal(t, MustUpto(2, 20, 3), Consume(MustUptoch(context.Background(), 2, 20, 3)))
+	assert.True(t, Settled(before), "exhausted channel leaked a goroutine")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	<-MustUptoch(ctx, 1<<20)
+	cancel()
+	assert.True(t, Settled(before), "abandoned channel leaked a goroutine")
+
+	seq, err := UptoSeq(nMax)
+	assert.NoError(t, err)
+	for e := range seq {
+		if e == 3 {
+			break
+		}
+	}
+	// polled rather than compared at once, as the goroutine may still be exiting
+	// assert.Eventually cannot do this, since it runs the condition on a goroutine of its own
+	assert.True(t, Settled(before), "broken sequence leaked a goroutine")
+
+	_, err = Uptoch[int](context.Background())
+	assert.Error(t, err)
+}
+
+func TestComplex128(t *testing.T) {
+	type myFloat float32
+	assert.Equal(t, complex128(3), Complex128(uint8(3)))
+	assert.Equal(t, complex128(-3), Complex128(-3))
+	assert.Equal(t, complex128(1.5), Complex128(myFloat(1.5)))
+	assert.Equal(t, 1-2i, Complex128(complex64(1-2i)))
+}
+
+func TestConjInPlace(t *testing.T) {
+	c64 := []complex64{1 + 2i, -3i}
+	ConjInPlace(c64)
+	assert.Equal(t, []complex64{1 - 2i, 3i}, c64)
+	type named complex128
+	cn := []named{1 + 2i}
+	ConjInPlace(cn)
+	assert.Equal(t, []named{1 - 2i}, cn)
+	ints := []int{-4, 5}
+	ConjInPlace(ints)
+	assert.Equal(t, []int{-4, 5}, ints)
+	assert.True(t, IsComplex[named]())
+	assert.True(t, IsInt[int8]())
+	assert.False(t, IsInt[float32]())
+	assert.False(t, IsInt[complex64]())
+	assert.True(t, IsUnsigned[uintptr]())
+	assert.False(t, IsUnsigned[int64]())
+	assert.False(t, IsUnsigned[float64]())
+	assert.False(t, IsComplex[float64]())
+}
@@ -1,17 +1,28 @@
 package oprs
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sync"
+	"text/tabwriter"
 )
 
-var DefaultPrinter = os.Stdout
+// DefaultPrinter is the writer used by the printers when they are given a nil writer
+var DefaultPrinter io.Writer = os.Stdout
+
+// orDefault returns the writer, or DefaultPrinter if it is nil
+func orDefault(w io.Writer) io.Writer {
+	return Ternary(w == nil, DefaultPrinter, w)
+}
 
 // Fprinter returns a closure that prints to the given writer
 // defaults to DefaultPrinter if writer is nil
 func Fprinter(w io.Writer) func(...any) (int, error) {
-	w = Ternary[io.Writer](w == nil, os.Stdout, w)
+	w = orDefault(w)
 	return func(a ...any) (int, error) {
 		return fmt.Fprint(w, a...)
 	}
@@ -20,7 +31,7 @@ func Fprinter(w io.Writer) func(...any) (int, error) {
 // Fprinterln returns a closure that prints a line to the given writer
 // defaults to DefaultPrinter if writer is nil
 func Fprinterln(w io.Writer) func(...any) (int, error) {
-	w = Ternary[io.Writer](w == nil, os.Stdout, w)
+	w = orDefault(w)
 	return func(a ...any) (int, error) {
 		return fmt.Fprintln(w, a...)
 	}
@@ -29,12 +40,47 @@ func Fprinterln(w io.Writer) func(...any) (int, error) {
 // Fprinterf returns a closure that prints a format string to the given writer
 // defaults to DefaultPrinter if writer is nil
 func Fprinterf(w io.Writer, format string) func(...any) (int, error) {
-	w = Ternary[io.Writer](w == nil, os.Stdout, w)
+	w = orDefault(w)
 	return func(a ...any) (int, error) {
 		return fmt.Fprintf(w, format, a...)
 	}
 }
 
+// Fprinterjson returns a closure that prints a value as a line of JSON to the given writer
+// defaults to DefaultPrinter if writer is nil
+func Fprinterjson(w io.Writer) func(any) (int, error) {
+	w = orDefault(w)
+	return func(a any) (int, error) {
+		line, err := json.Marshal(a)
+		if err != nil {
+			return 0, err
+		}
+		return w.Write(append(line, '\n'))
+	}
+}
+
+// Fprintertable returns a closure that prints rows as a table with aligned columns to the given writer
+// each call is aligned independently, so it should be given every row of the table at once
+// defaults to DefaultPrinter if writer is nil
+func Fprintertable(w io.Writer) func(...[]any) (int, error) {
+	w = orDefault(w)
+	return func(rows ...[]any) (int, error) {
+		var buf bytes.Buffer
+		tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		for _, row := range rows {
+			for i, cell := range row {
+				if i > 0 {
+					tw.Write([]byte{'\t'})
+				}
+				fmt.Fprint(tw, cell)
+			}
+			tw.Write([]byte{'\n'})
+		}
+		tw.Flush()
+		return w.Write(buf.Bytes())
+	}
+}
+
 // Printer returns a closure that prints to the given writer
 func Printer() func(...any) (int, error) {
 	return Fprinter(nil)
@@ -49,3 +95,96 @@ func Printerln() func(...any) (int, error) {
 func Printerf(format string) func(...any) (int, error) {
 	return Fprinterf(nil, format)
 }
+
+// Printerjson returns a closure that prints a value as a line of JSON to DefaultPrinter
+func Printerjson() func(any) (int, error) {
+	return Fprinterjson(nil)
+}
+
+// Printertable returns a closure that prints rows as a table with aligned columns to DefaultPrinter
+func Printertable() func(...[]any) (int, error) {
+	return Fprintertable(nil)
+}
+
+// prefixWriter starts every line with a prefix
+type prefixWriter struct {
+	w       io.Writer
+	prefix  func() string
+	midLine bool // the last write did not end a line
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	var buf bytes.Buffer
+	for _, line := range bytes.SplitAfter(b, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		if !p.midLine {
+			buf.WriteString(p.prefix())
+		}
+		buf.Write(line)
+		p.midLine = line[len(line)-1] != '\n'
+	}
+	if _, err := p.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// PrefixWriter returns a writer that starts every line written to w with the given prefix
+// defaults to DefaultPrinter if writer is nil
+func PrefixWriter(w io.Writer, prefix string) io.Writer {
+	return &prefixWriter{w: orDefault(w), prefix: func() string { return prefix }}
+}
+
+// TimestampWriter returns a writer that starts every line written to w with the
+// time, in the given layout, followed by a space
+// defaults to DefaultPrinter if writer is nil and SystemClock if clock is nil
+func TimestampWriter(w io.Writer, layout string, clock Clock) io.Writer {
+	Denull(&clock, SystemClock)
+	return &prefixWriter{w: orDefault(w), prefix: func() string { return clock.Now().Format(layout) + " " }}
+}
+
+// syncWriter guards a writer with a mutex
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(b)
+}
+
+// SyncWriter returns a writer that is safe for concurrent use
+// each call to Write reaches w whole, so lines printed by the closures in this file never interleave
+// defaults to DefaultPrinter if writer is nil
+func SyncWriter(w io.Writer) io.Writer {
+	return &syncWriter{w: orDefault(w)}
+}
+
+// tee duplicates writes to several writers
+type tee []io.Writer
+
+func (t tee) Write(b []byte) (int, error) {
+	var errs []error
+	for _, w := range t {
+		if n, err := w.Write(b); err != nil {
+			errs = append(errs, err)
+		} else if n < len(b) {
+			errs = append(errs, io.ErrShortWrite)
+		}
+	}
+	if len(errs) > 0 {
+		return 0, errors.Join(errs...)
+	}
+	return len(b), nil
+}
+
+// Tee returns a writer that duplicates its writes to all of the given writers
+// Unlike io.MultiWriter, a failing writer does not stop the others from being written to
+// the errors of every failing writer are joined together
+func Tee(ws ...io.Writer) io.Writer {
+	return tee(append([]io.Writer(nil), ws...))
+}
@@ -0,0 +1,100 @@
+package oprs
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultPrinter(t *testing.T) {
+	old := DefaultPrinter
+	defer func() { DefaultPrinter = old }()
+	var buf bytes.Buffer
+	DefaultPrinter = &buf
+
+	Printer()("a", 1)
+	Printerln()("b")
+	Printerf("%03d|")(7)
+	assert.Equal(t, "a1b\n007|", buf.String())
+}
+
+func TestFprinterjson(t *testing.T) {
+	var buf bytes.Buffer
+	print := Fprinterjson(&buf)
+	n, err := print(map[string]int{"a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 8, n)
+	print([]string{"x"})
+	assert.Equal(t, "{\"a\":1}\n[\"x\"]\n", buf.String())
+
+	_, err = print(func() {})
+	assert.Error(t, err)
+}
+
+func TestFprintertable(t *testing.T) {
+	var buf bytes.Buffer
+	Fprintertable(&buf)(
+		[]any{"name", "n"},
+		[]any{"alpha", 1},
+		[]any{"b", 22},
+	)
+	assert.Equal(t, "name   n\nalpha  1\nb      22\n", buf.String())
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := PrefixWriter(&buf, "> ")
+	n, err := w.Write([]byte("one\ntw"))
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+	w.Write([]byte("o\n\nthree\n"))
+	assert.Equal(t, "> one\n> two\n> \n> three\n", buf.String())
+
+	buf.Reset()
+	clock := &fakeClock{now: time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)}
+	Fprinterln(TimestampWriter(&buf, time.TimeOnly, clock))("up")
+	assert.Equal(t, "04:05:06 up\n", buf.String())
+}
+
+func TestSyncWriter(t *testing.T) {
+	var buf bytes.Buffer
+	print := Fprinterln(SyncWriter(&buf))
+	var wg sync.WaitGroup
+	for i := 0; i < nTests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			print(strings.Repeat("x", nItems))
+		}()
+	}
+	wg.Wait()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, nTests)
+	for _, line := range lines {
+		assert.Equal(t, strings.Repeat("x", nItems), line)
+	}
+}
+
+type failWriter struct{ err error }
+
+func (f failWriter) Write([]byte) (int, error) { return 0, f.err }
+
+func TestTee(t *testing.T) {
+	var a, b bytes.Buffer
+	n, err := Tee(&a, &b).Write([]byte("hi"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "hi", a.String())
+	assert.Equal(t, "hi", b.String())
+
+	e1, e2 := errors.New("one"), errors.New("two")
+	_, err = Tee(failWriter{e1}, &a, failWriter{e2}).Write([]byte("!"))
+	assert.ErrorIs(t, err, e1)
+	assert.ErrorIs(t, err, e2)
+	assert.Equal(t, "hi!", a.String())
+}
@@ -54,10 +54,10 @@ func Or[L, R any](one func(L) bool, two func(R) bool) func(L, R) bool {
 	}
 }
 
-// Or returns the && gate for non-boolean types
+// Xor returns the exclusive-or gate for non-boolean types
 func Xor[L, R any](one func(L) bool, two func(R) bool) func(L, R) bool {
 	return func(l L, r R) bool {
-		return !(one(l) && two(r))
+		return one(l) != two(r)
 	}
 }
 
@@ -82,18 +82,17 @@ func Either[T any](one, two func(T) bool) func(T) bool {
 	}
 }
 
-// NotBoth offers the XOR gate
+// NotBoth offers the NAND gate
 func NotBoth[T any](one, two func(T) bool) func(T) bool {
 	return Not(Both(one, two))
 }
 
 // All AND-concatenates a sequence of boolean operators with of a shared type
-// If no argument is given, the returned predicate will check if its argument's
-// pointer is nil
+// If no argument is given, the returned predicate is always true
 func All[T any](preds ...func(T) bool) func(T) bool {
 	switch len(preds) {
 	case 0:
-		return func(arg T) bool { return nil == &arg }
+		return func(T) bool { return true }
 	case 1:
 		return preds[0]
 	default:
@@ -106,12 +105,11 @@ func All[T any](preds ...func(T) bool) func(T) bool {
 }
 
 // Any OR-concatenates a sequence of boolean operators with of a shared type
-// If no argument is given, the returned predicate will check if its argument's
-// pointer is nil
+// If no argument is given, the returned predicate is always false
 func Any[T any](preds ...func(T) bool) func(T) bool {
 	switch len(preds) {
 	case 0:
-		return func(arg T) bool { return nil == &arg }
+		return func(T) bool { return false }
 	case 1:
 		return preds[0]
 	default:
@@ -123,20 +121,71 @@ func Any[T any](preds ...func(T) bool) func(T) bool {
 	}
 }
 
-// Any XOR-concatenates a sequence of boolean operators with of a shared type
-// If no argument is given, the returned predicate will check if its argument's
-// pointer is nil
+// One checks that exactly one of a sequence of boolean operators with of a shared type holds
+// If no argument is given, the returned predicate is always false
 func One[T any](preds ...func(T) bool) func(T) bool {
-	switch len(preds) {
-	case 0:
-		return func(arg T) bool { return nil == &arg }
-	case 1:
-		return preds[0]
-	default:
-		out := preds[0]
-		for _, pred := range preds[1:] {
-			out = NotBoth(out, pred)
+	return ExactlyN(1, preds...)
+}
+
+// Parity XOR-concatenates a sequence of boolean operators with of a shared type
+// ie, it checks that an odd number of them hold
+// Every predicate is evaluated, since the last one can always flip the result
+// If no argument is given, the returned predicate is always false
+func Parity[T any](preds ...func(T) bool) func(T) bool {
+	return func(arg T) bool {
+		out := false
+		for _, pred := range preds {
+			out = out != pred(arg)
 		}
 		return out
 	}
 }
+
+// ExactlyN checks that exactly n of a sequence of boolean operators with of a shared type hold
+// Evaluation stops as soon as the result is known
+func ExactlyN[T any](n int, preds ...func(T) bool) func(T) bool {
+	return func(arg T) bool {
+		count := 0
+		for i, pred := range preds {
+			if pred(arg) {
+				count++
+			}
+			if count > n || count+len(preds)-i-1 < n {
+				return false
+			}
+		}
+		return count == n
+	}
+}
+
+// AtLeastN checks that n or more of a sequence of boolean operators with of a shared type hold
+// Evaluation stops as soon as the result is known
+func AtLeastN[T any](n int, preds ...func(T) bool) func(T) bool {
+	return func(arg T) bool {
+		count := 0
+		for i, pred := range preds {
+			if count >= n {
+				return true
+			}
+			if count+len(preds)-i < n {
+				return false
+			}
+			if pred(arg) {
+				count++
+			}
+		}
+		return count >= n
+	}
+}
+
+// AtMostN checks that n or fewer of a sequence of boolean operators with of a shared type hold
+// Evaluation stops as soon as the result is known
+func AtMostN[T any](n int, preds ...func(T) bool) func(T) bool {
+	return Not(AtLeastN(n+1, preds...))
+}
+
+// Majority checks that more than half of a sequence of boolean operators with of a shared type hold
+// If no argument is given, the returned predicate is always false
+func Majority[T any](preds ...func(T) bool) func(T) bool {
+	return AtLeastN(len(preds)/2+1, preds...)
+}
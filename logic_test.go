@@ -0,0 +1,66 @@
+package oprs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// truths returns the predicates matching the bits of mask, recording which ones are called
+func truths(n int, mask uint, calls *int) []func(int) bool {
+	out := make([]func(int) bool, n)
+	for i := range out {
+		val := mask&(1<<i) != 0
+		out[i] = func(int) bool { *calls++; return val }
+	}
+	return out
+}
+
+func TestCountingPredicates(t *testing.T) {
+	const n = 5
+	for mask := uint(0); mask < 1<<n; mask++ {
+		count, calls := 0, 0
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				count++
+			}
+		}
+		preds := truths(n, mask, &calls)
+		assert.Equal(t, count%2 == 1, Parity(preds...)(0), "%05b", mask)
+		assert.Equal(t, count == 1, One(preds...)(0), "%05b", mask)
+		assert.Equal(t, count > n/2, Majority(preds...)(0), "%05b", mask)
+		for k := 0; k <= n; k++ {
+			assert.Equal(t, count == k, ExactlyN(k, preds...)(0), "%05b %d", mask, k)
+			assert.Equal(t, count >= k, AtLeastN(k, preds...)(0), "%05b %d", mask, k)
+			assert.Equal(t, count <= k, AtMostN(k, preds...)(0), "%05b %d", mask, k)
+		}
+	}
+}
+
+func TestShortCircuit(t *testing.T) {
+	calls := 0
+	assert.True(t, AtLeastN(2, truths(5, 0b00011, &calls)...)(0))
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	assert.False(t, ExactlyN(1, truths(5, 0b00011, &calls)...)(0))
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	assert.False(t, AtMostN(0, truths(5, 0b00001, &calls)...)(0))
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdentities(t *testing.T) {
+	assert.True(t, All[int]()(0))
+	assert.False(t, Any[int]()(0))
+	assert.False(t, One[int]()(0))
+	assert.False(t, Parity[int]()(0))
+	assert.False(t, Majority[int]()(0))
+
+	xor := Xor(IsEven[int], IsTrue)
+	assert.True(t, xor(2, false))
+	assert.True(t, xor(1, true))
+	assert.False(t, xor(2, true))
+	assert.False(t, xor(1, false))
+}
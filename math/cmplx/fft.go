@@ -0,0 +1,204 @@
+package cmplx
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+
+	"github.com/kendfss/rules"
+)
+
+// FFT returns the discrete Fourier transform of x
+//
+//	X[k] = Σ x[j]·exp(-2πi·jk/n)
+//
+// Power-of-two lengths use the radix-2 Cooley-Tukey algorithm, and other lengths
+// Bluestein's algorithm, so every length takes O(n log n) time
+// The transform is computed in complex128, whatever C is
+func FFT[C rules.Complex](x []C) []C {
+	return from128[C](fft(to128(x), false))
+}
+
+// IFFT returns the inverse discrete Fourier transform of X, so that IFFT(FFT(x)) ≈ x
+//
+//	x[j] = 1/n Σ X[k]·exp(2πi·jk/n)
+func IFFT[C rules.Complex](X []C) []C {
+	return from128[C](fft(to128(X), true))
+}
+
+// RFFT returns the first n/2+1 bins of the Fourier transform of real input,
+// the rest being the complex conjugates of these
+func RFFT[C rules.Complex, R rules.Real](x []R) []C {
+	in := make([]complex128, len(x))
+	for i, v := range x {
+		in[i] = complex(float64(v), 0)
+	}
+	out := fft(in, false)
+	return from128[C](out[:len(x)/2+1])
+}
+
+// IRFFT inverts RFFT, returning n real values from the first n/2+1 bins of their transform
+func IRFFT[R rules.Float, C rules.Complex](X []C, n int) []R {
+	full := make([]complex128, n)
+	for k := 0; k < n && k < len(X); k++ {
+		full[k] = complex128(X[k])
+		if k > 0 {
+			full[n-k] = cmplx.Conj(full[k])
+		}
+	}
+	out := make([]R, n)
+	for i, v := range fft(full, true) {
+		out[i] = R(real(v))
+	}
+	return out
+}
+
+// Convolve returns the linear convolution of a and b, whose length is len(a)+len(b)-1
+//
+//	out[k] = Σ a[j]·b[k-j]
+//
+// It multiplies their zero-padded transforms, taking O(n log n) time
+func Convolve[C rules.Complex](a, b []C) []C {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	n := len(a) + len(b) - 1
+	m := 1 << bits.Len(uint(n-1))
+	fa, fb := make([]complex128, m), make([]complex128, m)
+	for i, v := range a {
+		fa[i] = complex128(v)
+	}
+	for i, v := range b {
+		fb[i] = complex128(v)
+	}
+	fa, fb = fft(fa, false), fft(fb, false)
+	for i := range fa {
+		fa[i] *= fb[i]
+	}
+	return from128[C](fft(fa, true)[:n])
+}
+
+// FFTFreq returns the frequency of each bin of FFT's output, for n samples taken d apart
+// Non-negative frequencies come first, then the negative ones in increasing order
+func FFTFreq[R rules.Float](n int, d R) []R {
+	out := make([]R, n)
+	for i := range out {
+		k := i
+		if i > (n-1)/2 {
+			k = i - n
+		}
+		out[i] = R(k) / (d * R(n))
+	}
+	return out
+}
+
+// RFFTFreq returns the frequency of each bin of RFFT's output, for n samples taken d apart
+func RFFTFreq[R rules.Float](n int, d R) []R {
+	out := make([]R, n/2+1)
+	for i := range out {
+		out[i] = R(i) / (d * R(n))
+	}
+	return out
+}
+
+func to128[C rules.Complex](x []C) []complex128 {
+	out := make([]complex128, len(x))
+	for i, v := range x {
+		out[i] = complex128(v)
+	}
+	return out
+}
+
+func from128[C rules.Complex](x []complex128) []C {
+	out := make([]C, len(x))
+	for i, v := range x {
+		out[i] = C(v)
+	}
+	return out
+}
+
+// fft transforms x, which it may overwrite, scaling by 1/n if inverse
+func fft(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	switch {
+	case n <= 1:
+		return x
+	case n&(n-1) == 0:
+		radix2(x, inverse)
+	default:
+		x = bluestein(x, inverse)
+	}
+	if inverse {
+		for i := range x {
+			x[i] /= complex(float64(n), 0)
+		}
+	}
+	return x
+}
+
+// radix2 transforms x in place, without scaling, when its length is a power of two
+func radix2(x []complex128, inverse bool) {
+	n := len(x)
+	shift := 64 - bits.Len(uint(n-1))
+	for i := range x {
+		if j := int(bits.Reverse64(uint64(i)) >> shift); i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	// twiddles for the largest stage, shared by the smaller ones
+	w := make([]complex128, n/2)
+	for k := range w {
+		w[k] = cmplx.Rect(1, sign*2*math.Pi*float64(k)/float64(n))
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, stride := size/2, n/size
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				t := w[k*stride] * x[start+k+half]
+				x[start+k+half] = x[start+k] - t
+				x[start+k] += t
+			}
+		}
+	}
+}
+
+// bluestein transforms x, without scaling, by re-expressing the DFT as a
+// convolution with a chirp, which is then done with power-of-two transforms
+func bluestein(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	m := 1 << bits.Len(uint(2*n-2))
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	chirp := make([]complex128, n)
+	for k := range chirp {
+		// k² mod 2n keeps the angle small, and so accurate, for large k
+		sq := (k * k) % (2 * n)
+		chirp[k] = cmplx.Rect(1, sign*math.Pi*float64(sq)/float64(n))
+	}
+	a, b := make([]complex128, m), make([]complex128, m)
+	for k := range x {
+		a[k] = x[k] * chirp[k]
+	}
+	b[0] = cmplx.Conj(chirp[0])
+	for k := 1; k < n; k++ {
+		b[k] = cmplx.Conj(chirp[k])
+		b[m-k] = b[k]
+	}
+	radix2(a, false)
+	radix2(b, false)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	radix2(a, true)
+	out := make([]complex128, n)
+	for k := range out {
+		out[k] = chirp[k] * a[k] / complex(float64(m), 0)
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package cmplx
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// dft is the O(n²) definition that the fast transforms must match
+func dft(x []complex128) []complex128 {
+	out := make([]complex128, len(x))
+	for k := range out {
+		for j, v := range x {
+			out[k] += v * cmplx.Rect(1, -2*math.Pi*float64(j*k)/float64(len(x)))
+		}
+	}
+	return out
+}
+
+func randComplex(n int) []complex128 {
+	out := make([]complex128, n)
+	for i := range out {
+		out[i] = complex(rand.NormFloat64(), rand.NormFloat64())
+	}
+	return out
+}
+
+func assertClose[C complex64 | complex128](t *testing.T, want, have []C, delta float64, msgs ...any) {
+	t.Helper()
+	if assert.Len(t, have, len(want), msgs...) {
+		for i := range want {
+			assert.InDelta(t, 0, cmplx.Abs(complex128(want[i]-have[i])), delta, msgs...)
+		}
+	}
+}
+
+func TestFFT(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 5, 7, 8, 12, 16, 17, 100, 128, 1000} {
+		x := randComplex(n)
+		X := FFT(x)
+		assertClose(t, dft(x), X, 1e-9*float64(n+1), "n = %d", n)
+		assertClose(t, x, IFFT(X), 1e-12*float64(n+1), "n = %d", n)
+	}
+
+	x64 := []complex64{1, 2i, -1, 0.5}
+	assertClose(t, x64, IFFT(FFT(x64)), 1e-6)
+
+	// a pure tone lands in a single bin
+	tone := make([]complex128, 24)
+	for i := range tone {
+		tone[i] = cmplx.Rect(1, 2*math.Pi*3*float64(i)/24)
+	}
+	X := FFT(tone)
+	for k, v := range X {
+		assert.InDelta(t, map[bool]float64{true: 24, false: 0}[k == 3], cmplx.Abs(v), 1e-9, "bin %d", k)
+	}
+}
+
+func TestRFFT(t *testing.T) {
+	for _, n := range []int{1, 6, 7, 16} {
+		x := make([]float64, n)
+		full := make([]complex128, n)
+		for i := range x {
+			x[i] = rand.NormFloat64()
+			full[i] = complex(x[i], 0)
+		}
+		X := RFFT[complex128](x)
+		assertClose(t, FFT(full)[:n/2+1], X, 1e-10, "n = %d", n)
+		back := IRFFT[float64](X, n)
+		for i := range x {
+			assert.InDelta(t, x[i], back[i], 1e-12, "n = %d", n)
+		}
+	}
+	assertClose(t, []complex128{6, -2 + 2i, -2}, RFFT[complex128]([]int{0, 1, 2, 3}), 1e-12)
+}
+
+func TestConvolve(t *testing.T) {
+	assertClose(t, []complex128{4, 13, 28, 27, 18}, Convolve([]complex128{1, 2, 3}, []complex128{4, 5, 6}), 1e-12)
+	assert.Nil(t, Convolve([]complex128{}, []complex128{1}))
+
+	a, b := randComplex(13), randComplex(30)
+	want := make([]complex128, len(a)+len(b)-1)
+	for i := range a {
+		for j := range b {
+			want[i+j] += a[i] * b[j]
+		}
+	}
+	assertClose(t, want, Convolve(a, b), 1e-10)
+}
+
+func TestFreq(t *testing.T) {
+	assert.Equal(t, []float64{0, 1, 2, 3, -4, -3, -2, -1}, FFTFreq(8, 0.125))
+	assert.Equal(t, []float64{0, 0.2, 0.4, -0.4, -0.2}, FFTFreq(5, 1.0))
+	assert.Equal(t, []float32{0, 1, 2, 3, 4}, RFFTFreq[float32](8, 0.125))
+	assert.Equal(t, []float64{0, 0.2, 0.4}, RFFTFreq(5, 1.0))
+}
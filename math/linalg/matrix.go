@@ -0,0 +1,168 @@
+package linalg
+
+import (
+	"github.com/kendfss/oprs"
+	"github.com/kendfss/oprs/internal/tools"
+	"github.com/kendfss/rules"
+)
+
+// Matrix is a dense, row-major grid of numbers
+// Like a slice, copies of a Matrix share their elements
+type Matrix[N rules.Number] struct {
+	rows, cols int
+	data       []N
+}
+
+// NewMatrix builds a matrix from a copy of its rows, which must be equally long
+func NewMatrix[N rules.Number](rows ...[]N) (Matrix[N], error) {
+	if len(rows) == 0 {
+		return Matrix[N]{}, nil
+	}
+	m := Zeros[N](len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return Matrix[N]{}, &ShapeError{"NewMatrix", [2]int{1, m.cols}, [2]int{1, len(row)}}
+		}
+		copy(m.data[i*m.cols:], row)
+	}
+	return m, nil
+}
+
+// Zeros returns a matrix of the given shape filled with zeros
+func Zeros[N rules.Number](rows, cols int) Matrix[N] {
+	return Matrix[N]{rows, cols, make([]N, rows*cols)}
+}
+
+// Identity returns the n×n identity matrix
+func Identity[N rules.Number](n int) Matrix[N] {
+	m := Zeros[N](n, n)
+	for i := 0; i < n; i++ {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+// Shape returns the number of rows and columns
+func (m Matrix[N]) Shape() (rows, cols int) {
+	return m.rows, m.cols
+}
+
+func (m Matrix[N]) shape() [2]int {
+	return [2]int{m.rows, m.cols}
+}
+
+// At returns the element in row i and column j
+func (m Matrix[N]) At(i, j int) N {
+	return m.data[i*m.cols+j]
+}
+
+// Set replaces the element in row i and column j
+func (m Matrix[N]) Set(i, j int, val N) {
+	m.data[i*m.cols+j] = val
+}
+
+// Row returns a copy of row i
+func (m Matrix[N]) Row(i int) Vector[N] {
+	return append(Vector[N](nil), m.data[i*m.cols:(i+1)*m.cols]...)
+}
+
+// Col returns a copy of column j
+func (m Matrix[N]) Col(j int) Vector[N] {
+	out := make(Vector[N], m.rows)
+	for i := range out {
+		out[i] = m.At(i, j)
+	}
+	return out
+}
+
+// ToSlices returns a copy of the rows
+func (m Matrix[N]) ToSlices() [][]N {
+	out := make([][]N, m.rows)
+	for i := range out {
+		out[i] = m.Row(i)
+	}
+	return out
+}
+
+// Clone returns a matrix that does not share elements with m
+func (m Matrix[N]) Clone() Matrix[N] {
+	return Matrix[N]{m.rows, m.cols, append([]N(nil), m.data...)}
+}
+
+// Elementwise combines the elements of two equally shaped matrices with an operator, eg oprs.Add
+func (m Matrix[N]) Elementwise(o Matrix[N], op func(N, N) N) (Matrix[N], error) {
+	if m.shape() != o.shape() {
+		return Matrix[N]{}, &ShapeError{"Elementwise", m.shape(), o.shape()}
+	}
+	data, _ := Vector[N](m.data).Elementwise(o.data, op)
+	return Matrix[N]{m.rows, m.cols, data}, nil
+}
+
+// Add returns the sum of two matrices
+func (m Matrix[N]) Add(o Matrix[N]) (Matrix[N], error) {
+	return m.Elementwise(o, oprs.Add[N])
+}
+
+// Sub returns the difference of two matrices
+func (m Matrix[N]) Sub(o Matrix[N]) (Matrix[N], error) {
+	return m.Elementwise(o, oprs.Sub[N])
+}
+
+// Hadamard returns the elementwise product of two matrices
+func (m Matrix[N]) Hadamard(o Matrix[N]) (Matrix[N], error) {
+	return m.Elementwise(o, oprs.Mul[N])
+}
+
+// Scale multiplies every element by k
+func (m Matrix[N]) Scale(k N) Matrix[N] {
+	return Matrix[N]{m.rows, m.cols, Vector[N](m.data).Scale(k)}
+}
+
+// Mul returns the matrix product m×o
+func (m Matrix[N]) Mul(o Matrix[N]) (Matrix[N], error) {
+	if m.cols != o.rows {
+		return Matrix[N]{}, &ShapeError{"Mul", m.shape(), o.shape()}
+	}
+	out := Zeros[N](m.rows, o.cols)
+	for i := 0; i < m.rows; i++ {
+		for k := 0; k < m.cols; k++ {
+			a := m.At(i, k)
+			for j := 0; j < o.cols; j++ {
+				out.data[i*o.cols+j] += a * o.At(k, j)
+			}
+		}
+	}
+	return out, nil
+}
+
+// MulVec returns the matrix-vector product m×v
+func (m Matrix[N]) MulVec(v Vector[N]) (Vector[N], error) {
+	if m.cols != len(v) {
+		return nil, &ShapeError{"MulVec", m.shape(), v.shape()}
+	}
+	out := make(Vector[N], m.rows)
+	for i := range out {
+		for j, x := range v {
+			out[i] += m.At(i, j) * x
+		}
+	}
+	return out, nil
+}
+
+// T returns the transpose
+func (m Matrix[N]) T() Matrix[N] {
+	out := Zeros[N](m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			out.data[j*m.rows+i] = m.At(i, j)
+		}
+	}
+	return out
+}
+
+// H returns the conjugate transpose, which is the transpose for real types
+func (m Matrix[N]) H() Matrix[N] {
+	out := m.T()
+	tools.ConjInPlace(out.data)
+	return out
+}
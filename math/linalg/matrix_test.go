@@ -0,0 +1,64 @@
+package linalg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrix(t *testing.T) {
+	m, err := NewMatrix([]int{1, 2, 3}, []int{4, 5, 6})
+	assert.NoError(t, err)
+	rows, cols := m.Shape()
+	assert.Equal(t, 2, rows)
+	assert.Equal(t, 3, cols)
+	assert.Equal(t, 6, m.At(1, 2))
+	assert.Equal(t, Vector[int]{4, 5, 6}, m.Row(1))
+	assert.Equal(t, Vector[int]{2, 5}, m.Col(1))
+	assert.Equal(t, [][]int{{1, 4}, {2, 5}, {3, 6}}, m.T().ToSlices())
+
+	_, err = NewMatrix([]int{1, 2}, []int{3})
+	var serr *ShapeError
+	assert.ErrorAs(t, err, &serr)
+
+	prod, err := m.Mul(m.T())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{14, 32}, {32, 77}}, prod.ToSlices())
+	_, err = m.Mul(m)
+	if assert.ErrorAs(t, err, &serr) {
+		assert.Equal(t, "oprs.linalg: Mul: mismatched shapes 2x3 and 2x3", serr.Error())
+	}
+
+	mv, err := m.MulVec(Vector[int]{1, 0, -1})
+	assert.NoError(t, err)
+	assert.Equal(t, Vector[int]{-2, -2}, mv)
+	_, err = m.MulVec(Vector[int]{1})
+	assert.ErrorAs(t, err, &serr)
+
+	id, _ := Identity[int](3).Mul(m.T())
+	assert.Equal(t, m.T(), id)
+	sum, err := m.Add(m.Scale(2))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{3, 6, 9}, {12, 15, 18}}, sum.ToSlices())
+	diff, _ := sum.Sub(m)
+	had, _ := m.Hadamard(m)
+	assert.Equal(t, [][]int{{2, 4, 6}, {8, 10, 12}}, diff.ToSlices())
+	assert.Equal(t, [][]int{{1, 4, 9}, {16, 25, 36}}, had.ToSlices())
+	_, err = m.Add(m.T())
+	assert.ErrorAs(t, err, &serr)
+
+	assert.Equal(t, [][]float64{{0, 0}}, Zeros[float64](1, 2).ToSlices())
+	c := m.Clone()
+	c.Set(0, 0, 9)
+	assert.Equal(t, 1, m.At(0, 0))
+	assert.Equal(t, 9, c.At(0, 0))
+}
+
+func TestConjugateTranspose(t *testing.T) {
+	m, _ := NewMatrix([]complex64{1 + 2i, 3}, []complex64{-1i, 4 - 1i})
+	assert.Equal(t, [][]complex64{{1 - 2i, 1i}, {3, 4 + 1i}}, m.H().ToSlices())
+	assert.Equal(t, [][]complex64{{1 + 2i, -1i}, {3, 4 - 1i}}, m.T().ToSlices())
+
+	r, _ := NewMatrix([]float64{1, 2})
+	assert.Equal(t, r.T(), r.H())
+}
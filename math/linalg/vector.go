@@ -0,0 +1,128 @@
+// Package linalg offers vectors and matrices over any numeric type
+//
+// Operations whose operands must agree in shape report a *ShapeError instead
+// of panicking. Over complex types, inner products and norms follow the usual
+// Hermitian conventions
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"slices"
+
+	"github.com/kendfss/oprs"
+	"github.com/kendfss/oprs/internal/tools"
+	"github.com/kendfss/rules"
+)
+
+// ShapeError reports the shapes of operands that an operation cannot combine
+type ShapeError struct {
+	Op          string
+	Left, Right [2]int // rows and columns, a vector of length n is n×1
+}
+
+func (e *ShapeError) Error() string {
+	return fmt.Sprintf("oprs.linalg: %s: mismatched shapes %dx%d and %dx%d", e.Op, e.Left[0], e.Left[1], e.Right[0], e.Right[1])
+}
+
+// Vector is a column of numbers
+type Vector[N rules.Number] []N
+
+// shape returns the vector's dimensions as a column
+func (v Vector[N]) shape() [2]int {
+	return [2]int{len(v), 1}
+}
+
+// Elementwise combines the elements of two equally long vectors with an operator, eg oprs.Add
+func (v Vector[N]) Elementwise(w Vector[N], op func(N, N) N) (Vector[N], error) {
+	if len(v) != len(w) {
+		return nil, &ShapeError{"Elementwise", v.shape(), w.shape()}
+	}
+	out := make(Vector[N], len(v))
+	for i := range v {
+		out[i] = op(v[i], w[i])
+	}
+	return out, nil
+}
+
+// Add returns the sum of two vectors
+func (v Vector[N]) Add(w Vector[N]) (Vector[N], error) {
+	return v.Elementwise(w, oprs.Add[N])
+}
+
+// Sub returns the difference of two vectors
+func (v Vector[N]) Sub(w Vector[N]) (Vector[N], error) {
+	return v.Elementwise(w, oprs.Sub[N])
+}
+
+// Hadamard returns the elementwise product of two vectors
+func (v Vector[N]) Hadamard(w Vector[N]) (Vector[N], error) {
+	return v.Elementwise(w, oprs.Mul[N])
+}
+
+// Scale multiplies every element by k
+func (v Vector[N]) Scale(k N) Vector[N] {
+	return oprs.Integrate(oprs.Bind(oprs.Mul[N], k))(v)
+}
+
+// Conj returns the complex conjugate of every element
+func (v Vector[N]) Conj() Vector[N] {
+	out := slices.Clone(v)
+	tools.ConjInPlace(out)
+	return out
+}
+
+// Dot returns the inner product of two equally long vectors, conjugating v's elements
+func (v Vector[N]) Dot(w Vector[N]) (N, error) {
+	if len(v) != len(w) {
+		return 0, &ShapeError{"Dot", v.shape(), w.shape()}
+	}
+	if tools.IsComplex[N]() {
+		v = v.Conj()
+	}
+	var out N
+	for i := range v {
+		out += v[i] * w[i]
+	}
+	return out, nil
+}
+
+// Cross returns the cross product of two vectors of length 3
+func (v Vector[N]) Cross(w Vector[N]) (Vector[N], error) {
+	if len(v) != 3 || len(w) != 3 {
+		return nil, &ShapeError{"Cross", v.shape(), w.shape()}
+	}
+	return Vector[N]{
+		v[1]*w[2] - v[2]*w[1],
+		v[2]*w[0] - v[0]*w[2],
+		v[0]*w[1] - v[1]*w[0],
+	}, nil
+}
+
+// Norm returns the p-norm, (Σ|vᵢ|ᵖ)^(1/p), for p >= 1
+// p = math.Inf(1) gives the largest magnitude, and smaller p give NaN
+func (v Vector[N]) Norm(p float64) float64 {
+	switch {
+	case !(p >= 1):
+		return math.NaN()
+	case math.IsInf(p, 1):
+		out := 0.0
+		for _, x := range v {
+			out = max(out, cmplx.Abs(tools.Complex128(x)))
+		}
+		return out
+	case p == 2:
+		// accumulate with Hypot to avoid overflow on large elements
+		out := 0.0
+		for _, x := range v {
+			out = math.Hypot(out, cmplx.Abs(tools.Complex128(x)))
+		}
+		return out
+	}
+	sum := 0.0
+	for _, x := range v {
+		sum += math.Pow(cmplx.Abs(tools.Complex128(x)), p)
+	}
+	return math.Pow(sum, 1/p)
+}
@@ -0,0 +1,63 @@
+package linalg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kendfss/oprs"
+)
+
+func TestVectorOps(t *testing.T) {
+	v, w := Vector[int]{1, 2, 3}, Vector[int]{4, 5, 6}
+	sum, err := v.Add(w)
+	assert.NoError(t, err)
+	assert.Equal(t, Vector[int]{5, 7, 9}, sum)
+	diff, _ := w.Sub(v)
+	assert.Equal(t, Vector[int]{3, 3, 3}, diff)
+	prod, _ := v.Hadamard(w)
+	assert.Equal(t, Vector[int]{4, 10, 18}, prod)
+	quo, _ := w.Elementwise(v, oprs.Div[int])
+	assert.Equal(t, Vector[int]{4, 2, 2}, quo)
+	assert.Equal(t, Vector[int]{2, 4, 6}, v.Scale(2))
+
+	dot, err := v.Dot(w)
+	assert.NoError(t, err)
+	assert.Equal(t, 32, dot)
+	cross, err := v.Cross(w)
+	assert.NoError(t, err)
+	assert.Equal(t, Vector[int]{-3, 6, -3}, cross)
+
+	_, err = v.Add(w[:2])
+	var serr *ShapeError
+	if assert.ErrorAs(t, err, &serr) {
+		assert.Equal(t, [2]int{3, 1}, serr.Left)
+		assert.Equal(t, [2]int{2, 1}, serr.Right)
+	}
+	_, err = v.Dot(nil)
+	assert.ErrorAs(t, err, &serr)
+	_, err = v[:2].Cross(w[:2])
+	assert.ErrorAs(t, err, &serr)
+}
+
+func TestVectorComplex(t *testing.T) {
+	v := Vector[complex128]{1 + 1i, 2i}
+	dot, err := v.Dot(v)
+	assert.NoError(t, err)
+	assert.Equal(t, complex128(6), dot, "<v, v> is |v|² over complex numbers")
+	assert.Equal(t, Vector[complex128]{1 - 1i, -2i}, v.Conj())
+	assert.InDelta(t, math.Sqrt(6), v.Norm(2), 1e-12)
+	assert.InDelta(t, 2.0, v.Norm(math.Inf(1)), 1e-12)
+}
+
+func TestNorm(t *testing.T) {
+	v := Vector[float64]{3, -4}
+	assert.Equal(t, 7.0, v.Norm(1))
+	assert.Equal(t, 5.0, v.Norm(2))
+	assert.InDelta(t, math.Cbrt(91), v.Norm(3), 1e-12)
+	assert.Equal(t, 4.0, v.Norm(math.Inf(1)))
+	assert.True(t, math.IsNaN(v.Norm(0.5)))
+	assert.Equal(t, 0.0, Vector[int]{}.Norm(2))
+	assert.Equal(t, 5e300, Vector[float64]{3e300, 4e300}.Norm(2))
+}
@@ -0,0 +1,116 @@
+package poly
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kendfss/oprs"
+	"github.com/kendfss/oprs/internal/tools"
+	"github.com/kendfss/rules"
+)
+
+// MaxParseDegree bounds the powers accepted by Parse, to keep "x^1000000000" from exhausting memory
+const MaxParseDegree = 1 << 16
+
+// Parse reads a polynomial in x, such as "3x^2 - x + 0.5", the form produced by String
+// Terms may come in any order, repeated powers are summed, a "*" may separate a
+// coefficient from x, and complex coefficients must be parenthesised, eg "(1+2i)x"
+func Parse[N rules.Number](s string) (Poly[N], error) {
+	src := strings.Join(strings.Fields(s), "")
+	if src == "" {
+		return nil, fmt.Errorf("oprs.poly: empty polynomial: %w", strconv.ErrSyntax)
+	}
+	var out Poly[N]
+	depth, start := 0, 0
+	for i := 0; i <= len(src); i++ {
+		if i < len(src) {
+			switch src[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				continue
+			case '+', '-':
+				if depth > 0 || i == start || strings.IndexByte("eE^", src[i-1]) >= 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		coef, deg, err := parseTerm[N](src[start:i])
+		if err != nil {
+			return nil, err
+		}
+		for len(out) <= deg {
+			out = append(out, 0)
+		}
+		out[deg] += coef
+		start = i
+	}
+	return out.trim(), nil
+}
+
+// parseTerm reads a single signed term, such as "-2x^3"
+func parseTerm[N rules.Number](term string) (coef N, deg int, err error) {
+	fail := func(err error) (N, int, error) {
+		return 0, 0, fmt.Errorf("oprs.poly: invalid term %q: %w", term, err)
+	}
+	body, neg := term, false
+	if body != "" && (body[0] == '+' || body[0] == '-') {
+		body, neg = body[1:], body[0] == '-'
+	}
+
+	digits := body
+	if i := strings.IndexByte(body, 'x'); i >= 0 {
+		digits, deg = strings.TrimSuffix(body[:i], "*"), 1
+		if power := body[i+1:]; power != "" {
+			if !strings.HasPrefix(power, "^") {
+				return fail(strconv.ErrSyntax)
+			}
+			if deg, err = strconv.Atoi(power[1:]); err != nil || deg < 0 {
+				return fail(strconv.ErrSyntax)
+			}
+			if deg > MaxParseDegree {
+				return fail(strconv.ErrRange)
+			}
+		}
+		if digits == "" {
+			digits = "1"
+		}
+	}
+
+	if inner, ok := strings.CutPrefix(digits, "("); ok {
+		if inner, ok = strings.CutSuffix(inner, ")"); !ok {
+			return fail(strconv.ErrSyntax)
+		}
+		if coef, err = oprs.TryParseFloat[N](inner); err != nil {
+			return fail(err)
+		}
+		if neg {
+			if tools.IsUnsigned[N]() && coef != 0 {
+				return fail(oprs.ErrSign)
+			}
+			coef = 0 - coef
+		}
+		return coef, deg, nil
+	}
+	switch {
+	case neg && tools.IsUnsigned[N]():
+		// an unsigned coefficient can only be negated if it is zero
+		if coef, err = oprs.TryParseFloat[N](digits); err == nil && coef != 0 {
+			err = oprs.ErrSign
+		}
+	case neg:
+		// the sign stays on the digits, so that the most negative integer parses
+		coef, err = oprs.TryParseFloat[N]("-" + digits)
+	default:
+		coef, err = oprs.TryParseFloat[N](digits)
+	}
+	if err != nil {
+		return fail(err)
+	}
+	return coef, deg, nil
+}
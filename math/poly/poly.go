@@ -0,0 +1,198 @@
+// Package poly offers polynomials in one variable over any numeric type
+package poly
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kendfss/oprs/internal/tools"
+	"github.com/kendfss/rules"
+)
+
+var (
+	// ErrZeroDivisor is reported when dividing by the zero polynomial
+	ErrZeroDivisor = errors.New("division by the zero polynomial")
+	// ErrInexact is reported when integer division would have to truncate a coefficient
+	ErrInexact = errors.New("inexact division")
+)
+
+// Poly holds the coefficients of a polynomial, from the constant term upwards,
+// so that p[i] is the coefficient of xⁱ
+// The results of its methods never have trailing zeros, and the zero polynomial is empty
+type Poly[N rules.Number] []N
+
+// New returns the polynomial with the given coefficients, from the constant term upwards
+func New[N rules.Number](coeffs ...N) Poly[N] {
+	return Poly[N](append([]N(nil), coeffs...)).trim()
+}
+
+// trim drops zero coefficients from the top
+func (p Poly[N]) trim() Poly[N] {
+	for len(p) > 0 && p[len(p)-1] == 0 {
+		p = p[:len(p)-1]
+	}
+	if len(p) == 0 {
+		return nil
+	}
+	return p
+}
+
+// Degree returns the highest power with a non-zero coefficient, or -1 for the zero polynomial
+func (p Poly[N]) Degree() int {
+	return len(p.trim()) - 1
+}
+
+// Eval evaluates the polynomial at x by Horner's method
+func (p Poly[N]) Eval(x N) N {
+	var out N
+	for i := len(p) - 1; i >= 0; i-- {
+		out = out*x + p[i]
+	}
+	return out
+}
+
+// Add returns p + q
+func (p Poly[N]) Add(q Poly[N]) Poly[N] {
+	out := make(Poly[N], max(len(p), len(q)))
+	copy(out, p)
+	for i, c := range q {
+		out[i] += c
+	}
+	return out.trim()
+}
+
+// Sub returns p - q
+func (p Poly[N]) Sub(q Poly[N]) Poly[N] {
+	out := make(Poly[N], max(len(p), len(q)))
+	copy(out, p)
+	for i, c := range q {
+		out[i] -= c
+	}
+	return out.trim()
+}
+
+// Scale multiplies every coefficient by k
+func (p Poly[N]) Scale(k N) Poly[N] {
+	out := make(Poly[N], len(p))
+	for i, c := range p {
+		out[i] = c * k
+	}
+	return out.trim()
+}
+
+// Mul returns p × q
+func (p Poly[N]) Mul(q Poly[N]) Poly[N] {
+	p, q = p.trim(), q.trim()
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	out := make(Poly[N], len(p)+len(q)-1)
+	for i, a := range p {
+		for j, b := range q {
+			out[i+j] += a * b
+		}
+	}
+	return out.trim()
+}
+
+// DivMod divides p by q, returning the quotient and a remainder of lower degree than q
+// Over integer types, it reports ErrInexact unless every step of the long division is exact
+func (p Poly[N]) DivMod(q Poly[N]) (quo, rem Poly[N], err error) {
+	q = q.trim()
+	if len(q) == 0 {
+		return nil, nil, ErrZeroDivisor
+	}
+	rem = append(Poly[N](nil), p.trim()...)
+	if len(rem) < len(q) {
+		return nil, rem, nil
+	}
+	lead := q[len(q)-1]
+	quo = make(Poly[N], len(rem)-len(q)+1)
+	for k := len(quo) - 1; k >= 0; k-- {
+		top := rem[k+len(q)-1]
+		c := top / lead
+		if tools.IsInt[N]() && c*lead != top {
+			return nil, nil, fmt.Errorf("oprs.poly: %v / %v: %w", top, lead, ErrInexact)
+		}
+		quo[k] = c
+		for j, b := range q {
+			rem[k+j] -= c * b
+		}
+		rem[k+len(q)-1] = 0 // cancelled exactly, whatever the rounding
+	}
+	return quo.trim(), rem.trim(), nil
+}
+
+// Derivative returns the derivative dp/dx
+func (p Poly[N]) Derivative() Poly[N] {
+	if len(p) < 2 {
+		return nil
+	}
+	out := make(Poly[N], len(p)-1)
+	var k N
+	for i := range out {
+		k++
+		out[i] = p[i+1] * k
+	}
+	return out.trim()
+}
+
+// Integral returns the antiderivative whose constant term is c
+// Over integer types, the coefficients are truncated
+func (p Poly[N]) Integral(c N) Poly[N] {
+	out := make(Poly[N], len(p)+1)
+	out[0] = c
+	var k N
+	for i, a := range p {
+		k++
+		out[i+1] = a / k
+	}
+	return out.trim()
+}
+
+// Compose returns p(q(x))
+func (p Poly[N]) Compose(q Poly[N]) Poly[N] {
+	var out Poly[N]
+	for i := len(p) - 1; i >= 0; i-- {
+		out = out.Mul(q).Add(Poly[N]{p[i]})
+	}
+	return out
+}
+
+// String formats the polynomial from the highest power down, eg "3x^2 - x + 0.5"
+// Complex coefficients are parenthesised, eg "(1+2i)x + (0-1i)"
+// The zero polynomial is "0"
+func (p Poly[N]) String() string {
+	p = p.trim()
+	if len(p) == 0 {
+		return "0"
+	}
+	var b strings.Builder
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i] == 0 {
+			continue
+		}
+		coef := fmt.Sprint(p[i])
+		neg := strings.HasPrefix(coef, "-")
+		coef = strings.TrimPrefix(coef, "-")
+		switch {
+		case b.Len() == 0 && neg:
+			b.WriteString("-")
+		case b.Len() > 0 && neg:
+			b.WriteString(" - ")
+		case b.Len() > 0:
+			b.WriteString(" + ")
+		}
+		if coef != "1" || i == 0 {
+			b.WriteString(coef)
+		}
+		switch {
+		case i == 1:
+			b.WriteString("x")
+		case i > 1:
+			fmt.Fprintf(&b, "x^%d", i)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,169 @@
+package poly
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kendfss/oprs"
+)
+
+const nTests = 10
+
+func TestArith(t *testing.T) {
+	p := New(1, -3, 0, 2) // 2x^3 - 3x + 1
+	assert.Equal(t, 3, p.Degree())
+	assert.Equal(t, -1, New(0, 0).Degree())
+	assert.Nil(t, New(0, 0))
+	assert.Equal(t, 1, p.Eval(0))
+	assert.Equal(t, 11, p.Eval(2))
+
+	q := New(-1, 1) // x - 1
+	assert.Equal(t, New(0, -2, 0, 2), p.Add(q))
+	assert.Equal(t, New(2, -4, 0, 2), p.Sub(q))
+	assert.Nil(t, p.Sub(p))
+	assert.Equal(t, New(-1, 4, -3, -2, 2), p.Mul(q))
+	assert.Equal(t, New(2, -6, 0, 4), p.Scale(2))
+	assert.Nil(t, p.Mul(nil))
+
+	quo, rem, err := p.DivMod(q)
+	assert.NoError(t, err)
+	assert.Equal(t, New(-1, 2, 2), quo)
+	assert.Nil(t, rem)
+	quo, rem, err = p.DivMod(New(0, 0, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, New(0, 2), quo)
+	assert.Equal(t, New(1, -3), rem)
+	_, _, err = p.DivMod(nil)
+	assert.ErrorIs(t, err, ErrZeroDivisor)
+	_, _, err = p.DivMod(New(1, 3))
+	assert.ErrorIs(t, err, ErrInexact)
+
+	fq, fr, err := New(1.0, -3, 0, 2).DivMod(New(1.0, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fq.Degree())
+	assert.Equal(t, 0, fr.Degree())
+	back := fq.Mul(New(1.0, 3)).Add(fr)
+	for i, c := range New(1.0, -3, 0, 2) {
+		assert.InDelta(t, c, back[i], 1e-12)
+	}
+
+	_, rem, _ = q.DivMod(p)
+	assert.Equal(t, q, rem)
+}
+
+func TestCalculus(t *testing.T) {
+	p := New(1.0, -3, 0, 2)
+	assert.Equal(t, New(-3.0, 0, 6), p.Derivative())
+	assert.Equal(t, New(5.0, 1, -1.5, 0, 0.5), p.Integral(5))
+	assert.Equal(t, New(0.0, 1, -1.5, 0, 0.5).Derivative(), p)
+	assert.Nil(t, New(7).Derivative())
+	assert.Equal(t, New(4), Poly[int](nil).Integral(4))
+
+	// p(x + 1)
+	comp := p.Compose(New(1.0, 1))
+	for x := -2.0; x <= 2; x += 0.5 {
+		assert.InDelta(t, p.Eval(x+1), comp.Eval(x), 1e-12)
+	}
+	assert.Equal(t, New(3), New(3).Compose(New(1, 1)))
+}
+
+func TestString(t *testing.T) {
+	for want, p := range map[string]Poly[int]{
+		"2x^3 - 3x + 1": New(1, -3, 0, 2),
+		"-x^2 + x":      New(0, 1, -1),
+		"-1":            New(-1),
+		"0":             nil,
+		"x":             New(0, 1),
+	} {
+		assert.Equal(t, want, p.String())
+		back, err := Parse[int](want)
+		assert.NoError(t, err, want)
+		assert.Equal(t, p, back)
+	}
+	assert.Equal(t, "0.5x^2 - 1.25", New(-1.25, 0, 0.5).String())
+	assert.Equal(t, "(1+2i)x + (0-1i)", New[complex128](-1i, 1+2i).String())
+	assert.Equal(t, "1e+21x", New(0, 1e21).String())
+}
+
+func TestParse(t *testing.T) {
+	p, err := Parse[float64](" 3 * x^2 -x+ 0.5 + x^2 - 2e-1")
+	assert.NoError(t, err)
+	assert.Equal(t, New(0.3, -1, 4), p)
+
+	c, err := Parse[complex128]("(1+2i)x - (0-1i) + 2")
+	assert.NoError(t, err)
+	assert.Equal(t, New[complex128](2+1i, 1+2i), c)
+	c, err = Parse[complex128](New[complex128](-1i, 1+2i).String())
+	assert.NoError(t, err)
+	assert.Equal(t, New[complex128](-1i, 1+2i), c)
+
+	u, err := Parse[uint8]("x^2 + 255")
+	assert.NoError(t, err)
+	assert.Equal(t, New[uint8](255, 0, 1), u)
+
+	for _, src := range []string{"", "3x^", "3x^-1", "2y", "x^2 +", "(1+2i x"} {
+		_, err := Parse[int](src)
+		assert.ErrorIs(t, err, strconv.ErrSyntax, src)
+	}
+	_, err = Parse[int]("1.5x")
+	assert.ErrorIs(t, err, oprs.ErrTruncated)
+	_, err = Parse[uint]("-x")
+	assert.ErrorIs(t, err, oprs.ErrSign)
+	_, err = Parse[uint]("-(3)")
+	assert.ErrorIs(t, err, oprs.ErrSign)
+	_, err = Parse[int8]("300x")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	_, err = Parse[float64]("x^1000000000")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	big, err := Parse[int](fmt.Sprintf("x^%d", MaxParseDegree))
+	assert.NoError(t, err)
+	assert.Equal(t, MaxParseDegree, big.Degree())
+}
+
+func TestRoots(t *testing.T) {
+	roots, err := New(1, 0, 1).Roots()
+	assert.NoError(t, err)
+	if assert.Len(t, roots, 2) {
+		assert.InDelta(t, 0, cmplx.Abs(roots[0]+1i), 1e-12)
+		assert.InDelta(t, 0, cmplx.Abs(roots[1]-1i), 1e-12)
+	}
+
+	// (x-1)^2 (x+2)
+	roots, err = New(2, -3, 0, 1).Roots()
+	assert.NoError(t, err)
+	if assert.Len(t, roots, 3) {
+		for i, want := range []complex128{-2, 1, 1} {
+			assert.InDelta(t, 0, cmplx.Abs(roots[i]-want), 1e-6, "%v", roots)
+		}
+	}
+
+	roots, err = New(5).Roots()
+	assert.NoError(t, err)
+	assert.Empty(t, roots)
+
+	for i := 0; i < nTests; i++ {
+		want := make([]complex128, 2+i%5)
+		p := New[complex128](1)
+		for j := range want {
+			want[j] = complex(rand.NormFloat64()*3, rand.NormFloat64()*3)
+			p = p.Mul(New(-want[j], 1))
+		}
+		p = p.Scale(complex(rand.Float64()+0.5, 0))
+		have, err := p.Roots()
+		assert.NoError(t, err)
+		for _, w := range want {
+			nearest := cmplx.Inf()
+			for _, h := range have {
+				if cmplx.Abs(h-w) < cmplx.Abs(nearest-w) {
+					nearest = h
+				}
+			}
+			assert.InDelta(t, 0, cmplx.Abs(nearest-w), 1e-8, "want %v in %v", w, have)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package poly
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"math/cmplx"
+	"slices"
+
+	"github.com/kendfss/oprs/internal/tools"
+)
+
+// ErrNoConvergence is reported when root finding runs out of iterations
+var ErrNoConvergence = errors.New("root finding did not converge")
+
+// maxRootIterations bounds the Durand-Kerner iteration
+const maxRootIterations = 1000
+
+// Roots returns every complex root of the polynomial, repeated by multiplicity,
+// ordered by real part and then imaginary part
+// It uses the Durand-Kerner method, which finds all roots at once. Simple roots are
+// accurate to about machine precision, roots of multiplicity m to about its m-th root
+// If the iteration does not settle, the best estimates are returned with ErrNoConvergence
+func (p Poly[N]) Roots() ([]complex128, error) {
+	p = p.trim()
+	n := len(p) - 1
+	if n < 1 {
+		return nil, nil
+	}
+	// make it monic
+	a := make([]complex128, n+1)
+	lead := tools.Complex128(p[n])
+	for i, c := range p {
+		a[i] = tools.Complex128(c) / lead
+	}
+
+	// start on a circle enclosing every root (Cauchy's bound), at angles that avoid symmetry
+	bound := 0.0
+	for _, c := range a[:n] {
+		bound = max(bound, cmplx.Abs(c))
+	}
+	z := make([]complex128, n)
+	for k := range z {
+		z[k] = cmplx.Rect(1+bound, 2*math.Pi*float64(k)/float64(n)+0.4)
+	}
+
+	converged := false
+	for iter := 0; iter < maxRootIterations && !converged; iter++ {
+		converged = true
+		for i, zi := range z {
+			val, scale := evalMonic(a, zi)
+			den := complex(1, 0)
+			for j, zj := range z {
+				if j != i {
+					den *= zi - zj
+				}
+			}
+			if den == 0 {
+				den = complex(math.SmallestNonzeroFloat64, 0)
+			}
+			step := val / den
+			z[i] = zi - step
+			// a root has converged when its step is negligible, or when the polynomial
+			// is as small there as rounding allows, as happens at multiple roots
+			if cmplx.Abs(step) > 1e-14*max(1, cmplx.Abs(zi)) && cmplx.Abs(val) > float64(n)*1e-15*scale {
+				converged = false
+			}
+		}
+	}
+
+	slices.SortFunc(z, func(x, y complex128) int {
+		return cmp.Or(cmp.Compare(real(x), real(y)), cmp.Compare(imag(x), imag(y)))
+	})
+	if !converged {
+		return z, ErrNoConvergence
+	}
+	return z, nil
+}
+
+// evalMonic evaluates a monic polynomial at z by Horner's method, along with
+// the magnitude of its terms, which bounds the rounding error of the result
+func evalMonic(a []complex128, z complex128) (val complex128, scale float64) {
+	abs := cmplx.Abs(z)
+	for i := len(a) - 1; i >= 0; i-- {
+		val = val*z + a[i]
+		scale = scale*abs + cmplx.Abs(a[i])
+	}
+	return val, scale
+}
@@ -0,0 +1,324 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	"github.com/kendfss/oprs"
+	"github.com/kendfss/oprs/internal/tools"
+	"github.com/kendfss/oprs/math/real"
+	"github.com/kendfss/rules"
+)
+
+// ErrZeroDenominator is reported when a Rat would have a denominator of zero
+var ErrZeroDenominator = errors.New("zero denominator")
+
+// Rat is an exact fraction of two integers of type I
+// It is always in lowest terms, with its sign carried by the numerator
+// The zero value is 0/1 and ready to use, and equal fractions compare equal with ==
+// Arithmetic reports an oprs.ErrOverflow, rather than wrapping, if a result
+// does not fit in I
+type Rat[I rules.Int] struct {
+	num  I
+	den1 I // the denominator minus one, so that the zero value is valid
+}
+
+// NewRat returns the fraction num/den in lowest terms
+func NewRat[I rules.Int](num, den I) (Rat[I], error) {
+	switch {
+	case den == 0:
+		return Rat[I]{}, fmt.Errorf("oprs.rat: %v/%v: %w", num, den, ErrZeroDenominator)
+	case num == 0:
+		return Rat[I]{}, nil
+	case num == den:
+		return Rat[I]{1, 0}, nil
+	}
+	g := real.GCD(num, den)
+	num, den = num/g, den/g
+	if den < 0 {
+		if lo, _ := tools.IntBounds[I](); num == lo || den == lo {
+			return Rat[I]{}, fmt.Errorf("oprs.rat: %v/%v: %w", num, den, oprs.ErrOverflow)
+		}
+		num, den = -num, -den
+	}
+	return Rat[I]{num, den - 1}, nil
+}
+
+// MustRat is like NewRat, but panics if the fraction is invalid
+func MustRat[I rules.Int](num, den I) Rat[I] {
+	r, err := NewRat(num, den)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// RatOf returns the whole number n as a fraction
+func RatOf[I rules.Int](n I) Rat[I] {
+	return Rat[I]{n, 0}
+}
+
+// Num returns the numerator, which carries the sign
+func (r Rat[I]) Num() I {
+	return r.num
+}
+
+// Den returns the denominator, which is always positive
+func (r Rat[I]) Den() I {
+	return r.den1 + 1
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of r
+func (r Rat[I]) Sign() int {
+	switch {
+	case r.num < 0:
+		return -1
+	case r.num > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsInt reports whether r is a whole number
+func (r Rat[I]) IsInt() bool {
+	return r.Den() == 1
+}
+
+// Add returns r + s
+func (r Rat[I]) Add(s Rat[I]) (Rat[I], error) {
+	return r.addsub(s, false)
+}
+
+// Sub returns r - s
+func (r Rat[I]) Sub(s Rat[I]) (Rat[I], error) {
+	return r.addsub(s, true)
+}
+
+// addsub computes a/b ± c/d as (a*(d/g) ± c*(b/g)) / (b/g*d) with g = gcd(b, d),
+// which keeps the intermediate products as small as possible
+func (r Rat[I]) addsub(s Rat[I], sub bool) (Rat[I], error) {
+	a, b, c, d := r.Num(), r.Den(), s.Num(), s.Den()
+	g := real.GCD(b, d)
+	x, ok1 := mulChecked(a, d/g)
+	y, ok2 := mulChecked(c, b/g)
+	den, ok3 := mulChecked(b/g, d)
+	num, ok4 := oprs.Ternary(sub, subChecked[I], addChecked[I])(x, y)
+	if !(ok1 && ok2 && ok3 && ok4) {
+		return Rat[I]{}, r.overflow(oprs.Ternary(sub, "-", "+"), s)
+	}
+	return NewRat(num, den)
+}
+
+// Mul returns r * s
+func (r Rat[I]) Mul(s Rat[I]) (Rat[I], error) {
+	a, b, c, d := r.Num(), r.Den(), s.Num(), s.Den()
+	if a == 0 || c == 0 {
+		return Rat[I]{}, nil
+	}
+	g1, g2 := real.GCD(a, d), real.GCD(c, b)
+	num, ok1 := mulChecked(a/g1, c/g2)
+	den, ok2 := mulChecked(b/g2, d/g1)
+	if !(ok1 && ok2) {
+		return Rat[I]{}, r.overflow("*", s)
+	}
+	return NewRat(num, den)
+}
+
+// Div returns r / s
+func (r Rat[I]) Div(s Rat[I]) (Rat[I], error) {
+	inv, err := s.Inv()
+	if err != nil {
+		return Rat[I]{}, err
+	}
+	return r.Mul(inv)
+}
+
+// Inv returns 1 / r
+func (r Rat[I]) Inv() (Rat[I], error) {
+	return NewRat(r.Den(), r.Num())
+}
+
+// Neg returns -r
+func (r Rat[I]) Neg() (Rat[I], error) {
+	return Rat[I]{}.Sub(r)
+}
+
+// Cmp returns -1, 0 or +1 as r is less than, equal to or greater than s
+// It never overflows
+func (r Rat[I]) Cmp(s Rat[I]) int {
+	if rs, ss := r.Sign(), s.Sign(); rs != ss {
+		return oprs.Ternary(rs < ss, -1, 1)
+	}
+	// compare |a|*d with |c|*b in 128 bits, then undo the sign
+	neg, a := magnitude(r.Num())
+	_, c := magnitude(s.Num())
+	_, b := magnitude(r.Den())
+	_, d := magnitude(s.Den())
+	adHi, adLo := bits.Mul64(a, d)
+	cbHi, cbLo := bits.Mul64(c, b)
+	out := 0
+	switch {
+	case adHi != cbHi:
+		out = oprs.Ternary(adHi < cbHi, -1, 1)
+	case adLo != cbLo:
+		out = oprs.Ternary(adLo < cbLo, -1, 1)
+	}
+	return oprs.Ternary(neg, -out, out)
+}
+
+// Float64 returns the nearest float64 to r
+func (r Rat[I]) Float64() float64 {
+	f, _ := r.big().Float64()
+	return f
+}
+
+// String formats r as "num/den", or "num" if it is a whole number
+func (r Rat[I]) String() string {
+	if r.IsInt() {
+		return fmt.Sprint(r.Num())
+	}
+	return fmt.Sprintf("%v/%v", r.Num(), r.Den())
+}
+
+// RatFromFloat returns the fraction closest to f whose denominator is at most maxDen
+// If maxDen is not positive, any denominator that fits in I is allowed
+func RatFromFloat[I rules.Int](f float64, maxDen I) (Rat[I], error) {
+	switch {
+	case math.IsNaN(f):
+		return Rat[I]{}, fmt.Errorf("oprs.rat: %v: %w", f, oprs.ErrNaN)
+	case math.IsInf(f, 0):
+		return Rat[I]{}, fmt.Errorf("oprs.rat: %v: %w", f, oprs.ErrOverflow)
+	}
+	if maxDen <= 0 {
+		_, maxDen = tools.IntBounds[I]()
+	}
+	x := new(big.Rat).SetFloat64(f)
+	out, err := ratFromBig[I](bestApprox(x, new(big.Int).SetUint64(uint64(maxDen))))
+	if err != nil {
+		return Rat[I]{}, fmt.Errorf("oprs.rat: %v: %w", f, err)
+	}
+	return out, nil
+}
+
+// ParseRat parses a fraction, such as "-3/4", a whole number or an exact decimal, such as "1.25"
+func ParseRat[I rules.Int](s string) (Rat[I], error) {
+	x, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Rat[I]{}, &strconv.NumError{Func: "ParseRat", Num: s, Err: strconv.ErrSyntax}
+	}
+	out, err := ratFromBig[I](x)
+	if err != nil {
+		return Rat[I]{}, &strconv.NumError{Func: "ParseRat", Num: s, Err: err}
+	}
+	return out, nil
+}
+
+// big converts r to a *big.Rat
+func (r Rat[I]) big() *big.Rat {
+	return new(big.Rat).SetFrac(bigOf(r.Num()), bigOf(r.Den()))
+}
+
+func (r Rat[I]) overflow(op string, s Rat[I]) error {
+	return fmt.Errorf("oprs.rat: %v %s %v: %w", r, op, s, oprs.ErrOverflow)
+}
+
+// bestApprox finds the closest fraction to x whose denominator is at most maxDen,
+// by walking its continued fraction and checking the last semiconvergent
+func bestApprox(x *big.Rat, maxDen *big.Int) *big.Rat {
+	if x.Denom().Cmp(maxDen) <= 0 {
+		return x
+	}
+	p0, q0, p1, q1 := big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	n, d := new(big.Int).Set(x.Num()), new(big.Int).Set(x.Denom())
+	a, tmp := new(big.Int), new(big.Int)
+	for d.Sign() != 0 {
+		a.Div(n, d) // floors, since d > 0
+		q2 := new(big.Int).Add(q0, tmp.Mul(a, q1))
+		if q2.Cmp(maxDen) > 0 {
+			break
+		}
+		p0, q0, p1, q1 = p1, q1, new(big.Int).Add(p0, tmp.Mul(a, p1)), q2
+		n, d = d, new(big.Int).Sub(n, tmp.Mul(a, d))
+	}
+	k := new(big.Int).Div(new(big.Int).Sub(maxDen, q0), q1)
+	semi := new(big.Rat).SetFrac(
+		new(big.Int).Add(p0, new(big.Int).Mul(k, p1)),
+		new(big.Int).Add(q0, new(big.Int).Mul(k, q1)),
+	)
+	conv := new(big.Rat).SetFrac(p1, q1)
+	distSemi := new(big.Rat).Abs(new(big.Rat).Sub(semi, x))
+	distConv := new(big.Rat).Abs(new(big.Rat).Sub(conv, x))
+	if distConv.Cmp(distSemi) <= 0 {
+		return conv
+	}
+	return semi
+}
+
+// ratFromBig converts a *big.Rat into a Rat, if its parts fit in I
+func ratFromBig[I rules.Int](x *big.Rat) (Rat[I], error) {
+	num, err := intFromBig[I](x.Num())
+	if err != nil {
+		return Rat[I]{}, err
+	}
+	den, err := intFromBig[I](x.Denom())
+	if err != nil {
+		return Rat[I]{}, err
+	}
+	return Rat[I]{num, den - 1}, nil
+}
+
+// intFromBig converts a *big.Int into I, if it fits
+func intFromBig[I rules.Int](x *big.Int) (I, error) {
+	lo, hi := tools.IntBounds[I]()
+	if x.Sign() < 0 && lo == 0 {
+		return 0, oprs.ErrSign
+	}
+	if x.Cmp(bigOf(lo)) < 0 || x.Cmp(bigOf(hi)) > 0 {
+		return 0, oprs.ErrOverflow
+	}
+	if x.Sign() < 0 {
+		return I(x.Int64()), nil
+	}
+	return I(x.Uint64()), nil
+}
+
+// bigOf converts an integer into a *big.Int
+func bigOf[I rules.Int](i I) *big.Int {
+	if i < 0 {
+		return big.NewInt(int64(i))
+	}
+	return new(big.Int).SetUint64(uint64(i))
+}
+
+// magnitude splits an integer into its sign and absolute value
+func magnitude[I rules.Int](i I) (neg bool, mag uint64) {
+	if i < 0 {
+		return true, uint64(-int64(i))
+	}
+	return false, uint64(i)
+}
+
+// mulChecked multiplies two integers, reporting whether the product fits in I
+func mulChecked[I rules.Int](a, b I) (I, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	return c, c/b == a && c/a == b
+}
+
+// addChecked adds two integers, reporting whether the sum fits in I
+func addChecked[I rules.Int](a, b I) (I, bool) {
+	c := a + b
+	return c, b == 0 || b > 0 && c > a || b < 0 && c < a
+}
+
+// subChecked subtracts two integers, reporting whether the difference fits in I
+func subChecked[I rules.Int](a, b I) (I, bool) {
+	c := a - b
+	return c, b == 0 || b > 0 && c < a || b < 0 && c > a
+}
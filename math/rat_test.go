@@ -0,0 +1,142 @@
+package math
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kendfss/oprs"
+)
+
+func TestNewRat(t *testing.T) {
+	r, err := NewRat(6, -8)
+	assert.NoError(t, err)
+	assert.Equal(t, -3, r.Num())
+	assert.Equal(t, 4, r.Den())
+	assert.Equal(t, "-3/4", r.String())
+	assert.Equal(t, "5", RatOf(5).String())
+	assert.Equal(t, "0", Rat[int]{}.String())
+	assert.Equal(t, MustRat(0, 1), MustRat(0, -7))
+
+	_, err = NewRat(1, 0)
+	assert.ErrorIs(t, err, ErrZeroDenominator)
+	_, err = NewRat[int8](1, -128)
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+	r8, err := NewRat[int8](-128, -2)
+	assert.NoError(t, err)
+	assert.Equal(t, "64", r8.String())
+}
+
+func TestRatArith(t *testing.T) {
+	half, third := MustRat(1, 2), MustRat(1, 3)
+	for _, c := range []struct {
+		have func() (Rat[int], error)
+		want string
+	}{
+		{func() (Rat[int], error) { return half.Add(third) }, "5/6"},
+		{func() (Rat[int], error) { return third.Sub(half) }, "-1/6"},
+		{func() (Rat[int], error) { return half.Mul(MustRat(-2, 3)) }, "-1/3"},
+		{func() (Rat[int], error) { return half.Div(third) }, "3/2"},
+		{func() (Rat[int], error) { return MustRat(3, 4).Add(RatOf(1)) }, "7/4"},
+		{func() (Rat[int], error) { return half.Neg() }, "-1/2"},
+		{func() (Rat[int], error) { return MustRat(-3, 4).Inv() }, "-4/3"},
+	} {
+		r, err := c.have()
+		assert.NoError(t, err)
+		assert.Equal(t, c.want, r.String())
+	}
+
+	_, err := half.Div(Rat[int]{})
+	assert.ErrorIs(t, err, ErrZeroDenominator)
+
+	big := MustRat[int8](100, 1)
+	_, err = big.Add(big)
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+	_, err = big.Mul(RatOf[int8](2))
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+	_, err = RatOf[int8](-128).Neg()
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+	_, err = MustRat[uint8](1, 3).Sub(MustRat[uint8](1, 2))
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+	_, err = MustRat[int8](1, 64).Add(MustRat[int8](1, 3))
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+	sum, err := MustRat[int8](1, 64).Add(MustRat[int8](1, 64))
+	assert.NoError(t, err)
+	assert.Equal(t, "1/32", sum.String())
+	// cross-reduction keeps the product in range
+	prod, err := MustRat[int8](120, 7).Mul(MustRat[int8](7, 120))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", prod.String())
+}
+
+func TestRatCmp(t *testing.T) {
+	assert.Equal(t, -1, MustRat(1, 3).Cmp(MustRat(1, 2)))
+	assert.Equal(t, 1, MustRat(-1, 3).Cmp(MustRat(-1, 2)))
+	assert.Equal(t, 0, MustRat(2, 4).Cmp(MustRat(1, 2)))
+	assert.Equal(t, -1, MustRat(-1, 2).Cmp(Rat[int]{}))
+	// the cross products overflow int64
+	a, b := MustRat[int64](math.MaxInt64, math.MaxInt64-1), MustRat[int64](math.MaxInt64-1, math.MaxInt64-2)
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 1, b.Cmp(a))
+	assert.Equal(t, -1, RatOf[int64](math.MinInt64).Cmp(RatOf[int64](math.MinInt64+1)))
+	assert.Equal(t, 1, RatOf[uint64](math.MaxUint64).Cmp(MustRat[uint64](math.MaxUint64-1, 1)))
+}
+
+func TestRatFloat(t *testing.T) {
+	assert.Equal(t, -0.75, MustRat(-3, 4).Float64())
+
+	for _, c := range []struct {
+		f      float64
+		maxDen int
+		want   string
+	}{
+		{math.Pi, 10, "22/7"},
+		{math.Pi, 1000, "355/113"},
+		{math.Pi, 100, "311/99"},
+		{-math.Pi, 7, "-22/7"},
+		{0.1, 0, "3602879701896397/36028797018963968"},
+		{0.1, 100, "1/10"},
+		{2.5, 1, "2"},
+		{0, 10, "0"},
+	} {
+		r, err := RatFromFloat(c.f, c.maxDen)
+		assert.NoError(t, err)
+		assert.Equal(t, c.want, r.String(), "%v within %v", c.f, c.maxDen)
+	}
+
+	_, err := RatFromFloat(math.NaN(), 10)
+	assert.ErrorIs(t, err, oprs.ErrNaN)
+	_, err = RatFromFloat(math.Inf(1), 10)
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+	_, err = RatFromFloat[int8](300, 10)
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+	_, err = RatFromFloat[uint](-0.5, 10)
+	assert.ErrorIs(t, err, oprs.ErrSign)
+}
+
+func TestParseRat(t *testing.T) {
+	for s, want := range map[string]string{
+		"-3/4":  "-3/4",
+		"6/-8":  "",
+		"10/5":  "2",
+		"-7":    "-7",
+		"1.25":  "5/4",
+		" 1/2 ": "",
+		"1/0":   "",
+		"x":     "",
+	} {
+		r, err := ParseRat[int](s)
+		if want == "" {
+			assert.ErrorIs(t, err, strconv.ErrSyntax, s)
+			continue
+		}
+		assert.NoError(t, err, s)
+		assert.Equal(t, want, r.String())
+		back, _ := ParseRat[int](r.String())
+		assert.Equal(t, r, back)
+	}
+	_, err := ParseRat[int8]("1/256")
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+}
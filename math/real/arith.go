@@ -0,0 +1,79 @@
+package real
+
+import "github.com/kendfss/oprs"
+
+// The functions in this file work in any arithmetic, so they can be used with
+// oprs.BigInt, oprs.BigRat and oprs.BigFloat as well as the built-in types.
+// AbsIn and GCDIn mirror Abs and GCD, which stay direct for the built-in types,
+// since calls through oprs.Arith cost several times as much. The others have no
+// exact namesake: Pow takes a real exponent, and MapVal divides before multiplying
+// and accepts complex numbers
+
+// AbsIn computes the absolute value of a number
+func AbsIn[T any](ar oprs.Arith[T], val T) T {
+	if ar.Cmp(val, ar.Zero()) < 0 {
+		return ar.Neg(val)
+	}
+	return val
+}
+
+// GCDIn returns the non-negative Greatest Common Divisor as per the Euclidean algorithm
+// GCDIn(0, 0) is 0
+func GCDIn[T any](ar oprs.IntArith[T], a, b T) T {
+	zero := ar.Zero()
+	for ar.Cmp(b, zero) != 0 {
+		a, b = b, ar.Mod(a, b)
+	}
+	return AbsIn[T](ar, a)
+}
+
+// LCMIn returns the non-negative Lowest Common Multiple of two integers
+// LCMIn is 0 if either of them is
+func LCMIn[T any](ar oprs.IntArith[T], a, b T) T {
+	zero := ar.Zero()
+	if ar.Cmp(a, zero) == 0 || ar.Cmp(b, zero) == 0 {
+		return zero
+	}
+	return AbsIn[T](ar, ar.Mul(ar.Div(a, GCDIn(ar, a, b)), b))
+}
+
+// PowIn raises a number to a non-negative integer power by repeated squaring
+func PowIn[T any](ar oprs.Arith[T], base T, exp uint) T {
+	out := ar.One()
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			out = ar.Mul(out, base)
+		}
+		base = ar.Mul(base, base)
+	}
+	return out
+}
+
+// MapValIn implements "map" from Java's Processing framework, like MapVal
+// it multiplies before dividing, so integer arithmetic loses less precision
+func MapValIn[T any](ar oprs.Arith[T], n, min1, max1, min2, max2 T) T {
+	return ar.Add(min2, ar.Div(ar.Mul(ar.Sub(max2, min2), ar.Sub(n, min1)), ar.Sub(max1, min1)))
+}
+
+// FactorialIn computes n!
+func FactorialIn[T any](ar oprs.Arith[T], n uint) T {
+	out := ar.One()
+	for i := uint(2); i <= n; i++ {
+		out = ar.Mul(out, ar.FromInt(int64(i)))
+	}
+	return out
+}
+
+// BinomialIn computes the number of ways to choose k items from n
+// it is 0 if k > n
+func BinomialIn[T any](ar oprs.Arith[T], n, k uint) T {
+	if k > n {
+		return ar.Zero()
+	}
+	k = min(k, n-k)
+	out := ar.One()
+	for i := uint(1); i <= k; i++ {
+		out = ar.Div(ar.Mul(out, ar.FromInt(int64(n-k+i))), ar.FromInt(int64(i)))
+	}
+	return out
+}
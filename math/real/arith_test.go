@@ -0,0 +1,37 @@
+package real
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kendfss/oprs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArithIn(t *testing.T) {
+	ni := oprs.NativeInt[int]{}
+	assert.Equal(t, 6, GCDIn[int](ni, -12, 18))
+	assert.Equal(t, 5, GCDIn[int](ni, 0, 5))
+	assert.Equal(t, 0, GCDIn[int](ni, 0, 0))
+	assert.Equal(t, 36, LCMIn[int](ni, 12, -18))
+	assert.Equal(t, 0, LCMIn[int](ni, 0, 7))
+	assert.Equal(t, 1024, PowIn[int](ni, 2, 10))
+	assert.Equal(t, 1, PowIn[int](ni, 7, 0))
+	assert.Equal(t, 3628800, FactorialIn[int](ni, 10))
+	assert.Equal(t, 252, BinomialIn[int](ni, 10, 5))
+	assert.Equal(t, 0, BinomialIn[int](ni, 3, 5))
+	assert.Equal(t, 5.0, MapValIn[float64](oprs.Native[float64]{}, 0.5, 0, 1, 0, 10))
+
+	bi := oprs.BigInt{}
+	assert.Equal(t, "30414093201713378043612608166064768844377641568960512000000000000", FactorialIn[*big.Int](bi, 50).String())
+	assert.Equal(t, "100891344545564193334812497256", BinomialIn[*big.Int](bi, 100, 50).String())
+	assert.Equal(t, "1267650600228229401496703205376", PowIn[*big.Int](bi, big.NewInt(2), 100).String())
+	a, b := PowIn[*big.Int](bi, big.NewInt(6), 40), PowIn[*big.Int](bi, big.NewInt(10), 30)
+	assert.Equal(t, PowIn[*big.Int](bi, big.NewInt(2), 30).String(), GCDIn[*big.Int](bi, a, b).String())
+
+	br := oprs.BigRat{}
+	half := big.NewRat(1, 2)
+	assert.Equal(t, "1/1024", PowIn[*big.Rat](br, half, 10).RatString())
+	assert.Equal(t, "1/2", AbsIn[*big.Rat](br, big.NewRat(-1, 2)).RatString())
+	assert.Equal(t, "1/3", MapValIn[*big.Rat](br, big.NewRat(1, 3), br.Zero(), br.One(), br.Zero(), br.One()).RatString())
+}
@@ -0,0 +1,472 @@
+package real
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/kendfss/rules"
+)
+
+var (
+	// ErrNoBracket is reported when a bracketing root finder is given an interval whose ends have the same sign
+	ErrNoBracket = errors.New("root not bracketed")
+	// ErrMaxIter is reported when a method reaches its iteration limit before its tolerance
+	ErrMaxIter = errors.New("iteration limit reached")
+	// ErrFlat is reported when a root finder meets a zero slope
+	ErrFlat = errors.New("zero slope")
+	// ErrNotFinite is reported when a method meets an infinite or NaN value
+	ErrNotFinite = errors.New("non-finite value")
+)
+
+// ConvergenceError reports why a numerical method stopped, and where it got to
+type ConvergenceError struct {
+	Method      string
+	Iter        int     // iterations completed
+	Estimate    float64 // the best estimate found
+	Uncertainty float64 // the estimated absolute error of Estimate
+	Cause       error
+}
+
+func (e *ConvergenceError) Error() string {
+	return fmt.Sprintf("oprs.calculus: %s: %v after %d iterations, estimate %g ± %g", e.Method, e.Cause, e.Iter, e.Estimate, e.Uncertainty)
+}
+
+func (e *ConvergenceError) Unwrap() error {
+	return e.Cause
+}
+
+// Tolerance tells the methods in this file when to stop
+// An estimate x has converged once its estimated error is at most Abs + Rel*|x|
+type Tolerance struct {
+	Abs, Rel float64
+	MaxIter  int // defaults to a per-method limit if not positive
+}
+
+// within reports whether an error estimate is small enough for the estimate x
+func (t Tolerance) within(err, x float64) bool {
+	return err <= t.Abs+t.Rel*math.Abs(x)
+}
+
+func (t Tolerance) maxIter(def int) int {
+	if t.MaxIter > 0 {
+		return t.MaxIter
+	}
+	return def
+}
+
+// float64ly lifts a function over R to one over float64
+func float64ly[R rules.Float](f func(R) R) func(float64) float64 {
+	return func(x float64) float64 {
+		return float64(f(R(x)))
+	}
+}
+
+func finite(xs ...float64) bool {
+	for _, x := range xs {
+		if math.IsInf(x, 0) || math.IsNaN(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// Bisect finds a root of f in [lo, hi], whose ends must have opposite signs, by repeated halving
+// It needs about log2((hi-lo)/tol) iterations, 100 by default
+func Bisect[R rules.Float](f func(R) R, lo, hi R, tol Tolerance) (R, error) {
+	g := float64ly(f)
+	a, b := float64(lo), float64(hi)
+	fa, fb := g(a), g(b)
+	switch {
+	case fa == 0:
+		return lo, nil
+	case fb == 0:
+		return hi, nil
+	case !finite(fa, fb):
+		return 0, &ConvergenceError{"Bisect", 0, a, math.Abs(b - a), ErrNotFinite}
+	case math.Signbit(fa) == math.Signbit(fb):
+		return 0, &ConvergenceError{"Bisect", 0, a, math.Abs(b - a), ErrNoBracket}
+	}
+	n := tol.maxIter(100)
+	for i := 1; i <= n; i++ {
+		mid := a + (b-a)/2
+		fm := g(mid)
+		if fm == 0 || tol.within(math.Abs(b-a)/2, mid) {
+			return R(mid), nil
+		}
+		if math.Signbit(fm) == math.Signbit(fa) {
+			a, fa = mid, fm
+		} else {
+			b = mid
+		}
+	}
+	mid := a + (b-a)/2
+	return R(mid), &ConvergenceError{"Bisect", n, mid, math.Abs(b-a) / 2, ErrMaxIter}
+}
+
+// Newton finds a root of f, near x0, by Newton-Raphson iteration with the derivative df
+// It converges quadratically near simple roots, but may wander from poor starting points
+// The default limit is 100 iterations
+func Newton[R rules.Float](f, df func(R) R, x0 R, tol Tolerance) (R, error) {
+	g, dg := float64ly(f), float64ly(df)
+	x, step := float64(x0), math.Inf(1)
+	n := tol.maxIter(100)
+	for i := 0; i < n; i++ {
+		fx, dfx := g(x), dg(x)
+		switch {
+		case fx == 0:
+			return R(x), nil
+		case !finite(fx, dfx):
+			return R(x), &ConvergenceError{"Newton", i, x, math.Abs(step), ErrNotFinite}
+		case dfx == 0:
+			return R(x), &ConvergenceError{"Newton", i, x, math.Abs(step), ErrFlat}
+		}
+		step = fx / dfx
+		x -= step
+		if tol.within(math.Abs(step), x) {
+			return R(x), nil
+		}
+	}
+	return R(x), &ConvergenceError{"Newton", n, x, math.Abs(step), ErrMaxIter}
+}
+
+// Secant finds a root of f, near x0 and x1, by the secant method, which needs no derivative
+// The default limit is 100 iterations
+func Secant[R rules.Float](f func(R) R, x0, x1 R, tol Tolerance) (R, error) {
+	g := float64ly(f)
+	a, b := float64(x0), float64(x1)
+	fa, fb := g(a), g(b)
+	n := tol.maxIter(100)
+	for i := 0; i < n; i++ {
+		switch {
+		case fb == 0:
+			return R(b), nil
+		case !finite(fa, fb):
+			return R(b), &ConvergenceError{"Secant", i, b, math.Abs(b - a), ErrNotFinite}
+		case fa == fb:
+			return R(b), &ConvergenceError{"Secant", i, b, math.Abs(b - a), ErrFlat}
+		}
+		step := fb * (b - a) / (fb - fa)
+		a, fa = b, fb
+		b -= step
+		fb = g(b)
+		if tol.within(math.Abs(step), b) {
+			return R(b), nil
+		}
+	}
+	return R(b), &ConvergenceError{"Secant", n, b, math.Abs(b - a), ErrMaxIter}
+}
+
+// Brent finds a root of f in [lo, hi], whose ends must have opposite signs, with Brent's method
+// It combines inverse quadratic interpolation with bisection, so it is as safe as Bisect,
+// and usually much faster. The default limit is 100 iterations
+func Brent[R rules.Float](f func(R) R, lo, hi R, tol Tolerance) (R, error) {
+	g := float64ly(f)
+	a, b := float64(lo), float64(hi)
+	fa, fb := g(a), g(b)
+	switch {
+	case fa == 0:
+		return lo, nil
+	case fb == 0:
+		return hi, nil
+	case !finite(fa, fb):
+		return 0, &ConvergenceError{"Brent", 0, b, math.Abs(b - a), ErrNotFinite}
+	case math.Signbit(fa) == math.Signbit(fb):
+		return 0, &ConvergenceError{"Brent", 0, b, math.Abs(b - a), ErrNoBracket}
+	}
+	c, fc := a, fa
+	d := b - a
+	e := d
+	n := tol.maxIter(100)
+	for i := 1; i <= n; i++ {
+		if math.Signbit(fb) == math.Signbit(fc) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		// b is the best estimate and the root lies between b and c
+		t := max((tol.Abs+tol.Rel*math.Abs(b))/2, 2*epsilon*math.Abs(b))
+		half := (c - b) / 2
+		if fb == 0 || tol.within(math.Abs(half), b) {
+			return R(b), nil
+		}
+		if math.Abs(e) >= t && math.Abs(fa) > math.Abs(fb) {
+			// interpolate
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p, q = 2*half*s, 1-s
+			} else {
+				q, r := fa/fc, fb/fc
+				p = s * (2*half*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+			if 2*p < min(3*half*q-math.Abs(t*q), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = half, half
+			}
+		} else {
+			d, e = half, half
+		}
+		a, fa = b, fb
+		if math.Abs(d) > t {
+			b += d
+		} else {
+			b += math.Copysign(t, half)
+		}
+		if fb = g(b); !finite(fb) {
+			return R(b), &ConvergenceError{"Brent", i, b, math.Abs(c - b), ErrNotFinite}
+		}
+	}
+	return R(b), &ConvergenceError{"Brent", n, b, math.Abs(c-b) / 2, ErrMaxIter}
+}
+
+// epsilon is the gap between 1 and the next float64
+const epsilon = 0x1p-52
+
+// central is the central difference (f(x+h) - f(x-h)) / 2h
+func central(g func(float64) float64, x, h float64) float64 {
+	return (g(x+h) - g(x-h)) / (2 * h)
+}
+
+// Derivative estimates f'(x) by central differences, starting with step h and
+// halving it until successive estimates agree, 30 times by default
+// If h is 0, it starts from 0.1 * max(|x|, 1)
+// It never steps below the point where rounding error outgrows truncation error,
+// where estimates can agree by accident, so tolerances near machine precision are
+// out of reach. If it does not converge, it returns the estimate with the smallest error
+func Derivative[R rules.Float](f func(R) R, x, h R, tol Tolerance) (R, error) {
+	if h == 0 {
+		h = R(0.1 * max(math.Abs(float64(x)), 1))
+	}
+	g := float64ly(f)
+	floor := math.Cbrt(epsilon) * max(math.Abs(float64(x)), 1)
+	step := math.Abs(float64(h))
+	prev := central(g, float64(x), step)
+	if !finite(prev) {
+		return R(prev), &ConvergenceError{"Derivative", 0, prev, math.Inf(1), ErrNotFinite}
+	}
+	best, bestErr := prev, math.Inf(1)
+	n := tol.maxIter(30)
+	i := 1
+	for ; i <= n && step/2 >= floor; i++ {
+		step /= 2
+		est := central(g, float64(x), step)
+		err := math.Abs(est - prev)
+		if !finite(est) {
+			return R(best), &ConvergenceError{"Derivative", i, best, bestErr, ErrNotFinite}
+		}
+		if err < bestErr {
+			best, bestErr = est, err
+		}
+		if tol.within(err, est) {
+			return R(est), nil
+		}
+		prev = est
+	}
+	return R(best), &ConvergenceError{"Derivative", i - 1, best, bestErr, ErrMaxIter}
+}
+
+// Richardson estimates f'(x) by Richardson extrapolation of central differences,
+// starting with step h and halving it on each iteration, 10 by default
+// If it does not converge, it returns the estimate with the smallest error
+func Richardson[R rules.Float](f func(R) R, x, h R, tol Tolerance) (R, error) {
+	if h == 0 {
+		h = R(0.1 * max(math.Abs(float64(x)), 1))
+	}
+	g := float64ly(f)
+	n := tol.maxIter(10)
+	step := float64(h)
+	prev := []float64{central(g, float64(x), step)}
+	best, bestErr := prev[0], math.Inf(1)
+	for i := 1; i <= n; i++ {
+		step /= 2
+		row := []float64{central(g, float64(x), step)}
+		for j := 1; j <= i; j++ {
+			pow := math.Pow(4, float64(j))
+			row = append(row, row[j-1]+(row[j-1]-prev[j-1])/(pow-1))
+		}
+		est := row[i]
+		err := math.Abs(est - prev[i-1])
+		if !finite(est) {
+			break
+		}
+		if err < bestErr {
+			best, bestErr = est, err
+		}
+		if tol.within(err, est) {
+			return R(est), nil
+		}
+		prev = row
+	}
+	return R(best), &ConvergenceError{"Richardson", n, best, bestErr, ErrMaxIter}
+}
+
+// simpson applies Simpson's rule to [a, b], given f at a, at the midpoint and at b
+func simpson(a, b, fa, fm, fb float64) float64 {
+	return (b - a) / 6 * (fa + 4*fm + fb)
+}
+
+// Simpson integrates f over [a, b] with the adaptive Simpson's rule,
+// repeatedly bisecting the subinterval with the largest error estimate
+// Each iteration is one bisection, and the default limit is 1000
+// It is exact for cubics, and suits integrands that are smooth but not analytic
+func Simpson[R rules.Float](f func(R) R, a, b R, tol Tolerance) (R, error) {
+	// each piece keeps f at its ends, midpoint and quarter points, and
+	// compares the rule over the whole with the sum over its halves
+	type piece struct {
+		a, b     float64
+		fs       [5]float64
+		est, err float64
+	}
+	g := float64ly(f)
+	newPiece := func(a, b, fa, fm, fb float64) piece {
+		m := (a + b) / 2
+		fl, fr := g((a+m)/2), g((m+b)/2)
+		halves := simpson(a, m, fa, fl, fm) + simpson(m, b, fm, fr, fb)
+		diff := halves - simpson(a, b, fa, fm, fb)
+		return piece{a, b, [5]float64{fa, fl, fm, fr, fb}, halves + diff/15, math.Abs(diff) / 15}
+	}
+	lo, hi := float64(a), float64(b)
+	pieces := []piece{newPiece(lo, hi, g(lo), g((lo+hi)/2), g(hi))}
+	n := tol.maxIter(1000)
+	for i := 0; ; i++ {
+		est, err := 0.0, 0.0
+		worst := 0
+		for j, p := range pieces {
+			est += p.est
+			err += p.err
+			if p.err > pieces[worst].err {
+				worst = j
+			}
+		}
+		switch {
+		case !finite(est, err):
+			return R(est), &ConvergenceError{"Simpson", i, est, err, ErrNotFinite}
+		case tol.within(err, est):
+			return R(est), nil
+		case i == n:
+			return R(est), &ConvergenceError{"Simpson", i, est, err, ErrMaxIter}
+		}
+		p := pieces[worst]
+		mid := (p.a + p.b) / 2
+		pieces[worst] = newPiece(p.a, mid, p.fs[0], p.fs[1], p.fs[2])
+		pieces = append(pieces, newPiece(mid, p.b, p.fs[2], p.fs[3], p.fs[4]))
+	}
+}
+
+// Romberg integrates f over [a, b] by Richardson extrapolation of the trapezoid rule
+// Each iteration doubles the number of evaluations, and the default limit is 20
+// It suits smooth integrands, for which it converges very quickly
+func Romberg[R rules.Float](f func(R) R, a, b R, tol Tolerance) (R, error) {
+	g := float64ly(f)
+	lo, hi := float64(a), float64(b)
+	h := hi - lo
+	prev := []float64{h / 2 * (g(lo) + g(hi))}
+	n := tol.maxIter(20)
+	err := math.Inf(1)
+	for i := 1; i <= n; i++ {
+		h /= 2
+		sum := 0.0
+		for k := 1; k < 1<<i; k += 2 {
+			sum += g(lo + float64(k)*h)
+		}
+		row := []float64{prev[0]/2 + h*sum}
+		for j := 1; j <= i; j++ {
+			pow := math.Pow(4, float64(j))
+			row = append(row, row[j-1]+(row[j-1]-prev[j-1])/(pow-1))
+		}
+		err = math.Abs(row[i] - prev[i-1])
+		if !finite(row[i]) {
+			return R(row[i]), &ConvergenceError{"Romberg", i, row[i], err, ErrNotFinite}
+		}
+		if i > 1 && tol.within(err, row[i]) {
+			return R(row[i]), nil
+		}
+		prev = row
+	}
+	return R(prev[n]), &ConvergenceError{"Romberg", n, prev[n], err, ErrMaxIter}
+}
+
+// The 15-point Kronrod rule and its embedded 7-point Gauss rule on [-1, 1]
+// The nodes are symmetric, from the outermost in, and the odd ones are the Gauss nodes
+var (
+	kronrodNodes = [8]float64{
+		0.991455371120812639206854697526329, 0.949107912342758524526189684047851,
+		0.864864423359769072789712788640926, 0.741531185599394439863864773280788,
+		0.586087235467691130294144845693013, 0.405845151377397166906606412076961,
+		0.207784955007898467600689403773245, 0,
+	}
+	kronrodWeights = [8]float64{
+		0.022935322010529224963732008058970, 0.063092092629978553290700663189204,
+		0.104790010322250183839876322541518, 0.140653259715525918745189590510238,
+		0.169004726639267902826583426598550, 0.190350578064785409913256402421014,
+		0.204432940075298892414161999234649, 0.209482141084727828012999174891714,
+	}
+	gaussWeights = [4]float64{
+		0.129484966168869693270611432679082, 0.279705391489276667901467771423780,
+		0.381830050505118944950369775488975, 0.417959183673469387755102040816327,
+	}
+)
+
+// kronrod applies the G7K15 pair to [a, b], returning the Kronrod estimate and
+// its difference from the Gauss estimate
+func kronrod(g func(float64) float64, a, b float64) (est, err float64) {
+	mid, half := (a+b)/2, (b-a)/2
+	fmid := g(mid)
+	k, gauss := fmid*kronrodWeights[7], fmid*gaussWeights[3]
+	for i, x := range kronrodNodes[:7] {
+		pair := g(mid-half*x) + g(mid+half*x)
+		k += kronrodWeights[i] * pair
+		if i%2 == 1 {
+			gauss += gaussWeights[i/2] * pair
+		}
+	}
+	return k * half, math.Abs((k - gauss) * half)
+}
+
+// GaussKronrod integrates f over [a, b] with the adaptive Gauss-Kronrod (G7K15) rule,
+// repeatedly bisecting the subinterval with the largest error estimate
+// Each iteration is one bisection, and the default limit is 1000
+// It copes well with integrands that are badly behaved in places
+func GaussKronrod[R rules.Float](f func(R) R, a, b R, tol Tolerance) (R, error) {
+	type piece struct{ a, b, est, err float64 }
+	g := float64ly(f)
+	est, err := kronrod(g, float64(a), float64(b))
+	pieces := []piece{{float64(a), float64(b), est, err}}
+	n := tol.maxIter(1000)
+	for i := 0; ; i++ {
+		est, err = 0, 0
+		worst := 0
+		for j, p := range pieces {
+			est += p.est
+			err += p.err
+			if p.err > pieces[worst].err {
+				worst = j
+			}
+		}
+		switch {
+		case !finite(est, err):
+			return R(est), &ConvergenceError{"GaussKronrod", i, est, err, ErrNotFinite}
+		case tol.within(err, est):
+			return R(est), nil
+		case i == n:
+			return R(est), &ConvergenceError{"GaussKronrod", i, est, err, ErrMaxIter}
+		}
+		p := pieces[worst]
+		mid := (p.a + p.b) / 2
+		e1, r1 := kronrod(g, p.a, mid)
+		e2, r2 := kronrod(g, mid, p.b)
+		pieces[worst] = piece{p.a, mid, e1, r1}
+		pieces = append(pieces, piece{mid, p.b, e2, r2})
+	}
+}
@@ -0,0 +1,144 @@
+package real
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootFinders(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2 }
+	df := func(x float64) float64 { return 2 * x }
+	tol := Tolerance{Abs: 1e-12}
+
+	for name, find := range map[string]func() (float64, error){
+		"Bisect": func() (float64, error) { return Bisect(f, 0, 2, tol) },
+		"Newton": func() (float64, error) { return Newton(f, df, 1, tol) },
+		"Secant": func() (float64, error) { return Secant(f, 1, 2, tol) },
+		"Brent":  func() (float64, error) { return Brent(f, 0, 2, tol) },
+	} {
+		root, err := find()
+		assert.NoError(t, err, name)
+		assert.InDelta(t, math.Sqrt2, root, 1e-11, name)
+	}
+
+	root32, err := Brent(func(x float32) float32 { return float32(math.Cos(float64(x))) - x }, 0, 1, Tolerance{Abs: 1e-6})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.7390851, root32, 1e-6)
+
+	// Brent should need far fewer evaluations than bisection
+	count := func(f func(float64) float64) (func(float64) float64, *int) {
+		n := 0
+		return func(x float64) float64 { n++; return f(x) }, &n
+	}
+	g, nBisect := count(f)
+	Bisect(g, 0, 2, tol)
+	h, nBrent := count(f)
+	Brent(h, 0, 2, tol)
+	assert.Less(t, *nBrent, *nBisect/2)
+}
+
+func TestRootFinderErrors(t *testing.T) {
+	f := func(x float64) float64 { return x*x + 1 }
+	var cerr *ConvergenceError
+
+	_, err := Bisect(f, -1, 1, Tolerance{Abs: 1e-9})
+	assert.ErrorIs(t, err, ErrNoBracket)
+	_, err = Brent(f, -1, 1, Tolerance{Abs: 1e-9})
+	assert.ErrorIs(t, err, ErrNoBracket)
+
+	_, err = Newton(f, func(x float64) float64 { return 2 * x }, 0, Tolerance{Abs: 1e-9})
+	assert.ErrorIs(t, err, ErrFlat)
+	_, err = Newton(f, func(x float64) float64 { return 2 * x }, 0.5, Tolerance{Abs: 1e-9, MaxIter: 20})
+	if assert.ErrorAs(t, err, &cerr) {
+		assert.ErrorIs(t, err, ErrMaxIter)
+		assert.Equal(t, 20, cerr.Iter)
+		assert.Equal(t, "Newton", cerr.Method)
+	}
+	_, err = Secant(func(float64) float64 { return 1 }, 0, 1, Tolerance{})
+	assert.ErrorIs(t, err, ErrFlat)
+	_, err = Secant(math.Log, -1, 0.5, Tolerance{})
+	assert.ErrorIs(t, err, ErrNotFinite)
+
+	est, err := Bisect(func(x float64) float64 { return x - 1.0/3 }, 0, 1, Tolerance{MaxIter: 10})
+	if assert.ErrorAs(t, err, &cerr) {
+		assert.InDelta(t, 1.0/3, est, cerr.Uncertainty)
+		assert.InDelta(t, 1.0/3, cerr.Estimate, cerr.Uncertainty)
+	}
+}
+
+func TestDerivatives(t *testing.T) {
+	d, err := Derivative(math.Sin, 1, 0, Tolerance{Abs: 1e-8})
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Cos(1), d, 1e-8)
+	d, err = Derivative(func(x float64) float64 { return x * x * x }, 1, 1e-3, Tolerance{Rel: 1e-6})
+	assert.NoError(t, err)
+	assert.InDelta(t, 3.0, d, 1e-5)
+
+	// rounding error swamps the differences before they agree this closely
+	var cerr *ConvergenceError
+	d, err = Derivative(math.Exp, 1, 0, Tolerance{Abs: 1e-15})
+	if assert.ErrorAs(t, err, &cerr) {
+		assert.ErrorIs(t, err, ErrMaxIter)
+		assert.Equal(t, "Derivative", cerr.Method)
+		assert.InDelta(t, math.E, d, 1e-6)
+	}
+	_, err = Derivative(math.Log, 0, 0, Tolerance{Abs: 1e-8})
+	assert.ErrorIs(t, err, ErrNotFinite)
+
+	d, err = Richardson(math.Exp, 2, 0, Tolerance{Abs: 1e-12, Rel: 1e-12})
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Exp(2), d, 1e-10)
+
+	d32, err := Richardson(func(x float32) float32 { return x * x }, 3, 0.5, Tolerance{Abs: 1e-4})
+	assert.NoError(t, err)
+	assert.InDelta(t, 6, d32, 1e-4)
+
+	// a kink at 0 defeats extrapolation
+	_, err = Richardson(math.Abs, 1e-9, 1, Tolerance{Abs: 1e-15, MaxIter: 5})
+	assert.ErrorIs(t, err, ErrMaxIter)
+}
+
+func TestIntegrals(t *testing.T) {
+	cube, err := Simpson(func(x float64) float64 { return x * x * x }, 0, 2, Tolerance{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, cube, "Simpson is exact for cubics")
+	_, err = Simpson(math.Sqrt, 0, 1, Tolerance{Abs: 1e-15, MaxIter: 10})
+	assert.ErrorIs(t, err, ErrMaxIter)
+
+	tol := Tolerance{Abs: 1e-12, Rel: 1e-12}
+	for name, integrate := range map[string]func(func(float64) float64, float64, float64) (float64, error){
+		"Romberg": func(f func(float64) float64, a, b float64) (float64, error) {
+			return Romberg(f, a, b, tol)
+		},
+		"GaussKronrod": func(f func(float64) float64, a, b float64) (float64, error) {
+			return GaussKronrod(f, a, b, tol)
+		},
+		"Simpson": func(f func(float64) float64, a, b float64) (float64, error) {
+			return Simpson(f, a, b, tol)
+		},
+	} {
+		v, err := integrate(math.Sin, 0, math.Pi)
+		assert.NoError(t, err, name)
+		assert.InDelta(t, 2.0, v, 1e-10, name)
+		v, err = integrate(math.Exp, 0, 1)
+		assert.NoError(t, err, name)
+		assert.InDelta(t, math.E-1, v, 1e-10, name)
+		v, err = integrate(func(x float64) float64 { return 4 / (1 + x*x) }, 0, 1)
+		assert.NoError(t, err, name)
+		assert.InDelta(t, math.Pi, v, 1e-10, name)
+	}
+
+	// a singular derivative at 0 needs adaptivity
+	v, err := GaussKronrod(math.Sqrt, 0, 1, Tolerance{Abs: 1e-10})
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0/3, v, 1e-10)
+	_, err = Romberg(math.Sqrt, 0, 1, Tolerance{Abs: 1e-14, MaxIter: 8})
+	assert.ErrorIs(t, err, ErrMaxIter)
+	// a divergent integral never settles
+	_, err = GaussKronrod(func(x float64) float64 { return 1 / x }, 0, 1, Tolerance{Abs: 1e-10, MaxIter: 50})
+	assert.ErrorIs(t, err, ErrMaxIter)
+	_, err = GaussKronrod(func(x float64) float64 { return 1 / (x - 0.5) }, 0, 1, Tolerance{Abs: 1e-10})
+	assert.ErrorIs(t, err, ErrNotFinite)
+}
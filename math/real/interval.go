@@ -0,0 +1,426 @@
+package real
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/kendfss/oprs/internal/tools"
+	"github.com/kendfss/rules"
+)
+
+var (
+	// ErrInterval is reported for intervals whose ends are NaN or out of order
+	ErrInterval = errors.New("invalid interval")
+	// ErrDivByZero is reported by Div when the divisor contains zero
+	ErrDivByZero = errors.New("division by an interval containing zero")
+	// ErrDomain is reported when an interval lies wholly outside a function's domain
+	ErrDomain = errors.New("outside domain")
+)
+
+// Interval is the closed range of values [Lo, Hi], used to bound the error of a computation
+// Over floating point types, every operation rounds outwards with Nextafter, so the
+// result always contains the exact result for every choice of operands.
+// Over integer types, operations are exact, and ends that overflow saturate at the
+// bounds of R, so the result is clamped to Entire rather than wrapping around
+type Interval[R rules.Real] struct {
+	Lo, Hi R
+}
+
+// NewInterval returns the interval [lo, hi]
+func NewInterval[R rules.Real](lo, hi R) (Interval[R], error) {
+	if !(lo <= hi) {
+		return Interval[R]{}, fmt.Errorf("oprs.interval: [%v, %v]: %w", lo, hi, ErrInterval)
+	}
+	return Interval[R]{lo, hi}, nil
+}
+
+// Point returns the interval containing only x
+func Point[R rules.Real](x R) Interval[R] {
+	return Interval[R]{x, x}
+}
+
+// Entire returns the interval of every value of R, from -Inf to +Inf for floating point types
+func Entire[R rules.Real]() Interval[R] {
+	return outward[R](math.Inf(-1), math.Inf(1), 0)
+}
+
+func (a Interval[R]) String() string {
+	return fmt.Sprintf("[%v, %v]", a.Lo, a.Hi)
+}
+
+// Width returns Hi - Lo
+func (a Interval[R]) Width() R {
+	return a.Hi - a.Lo
+}
+
+// Mid returns the midpoint
+func (a Interval[R]) Mid() R {
+	return a.Lo + (a.Hi-a.Lo)/2
+}
+
+// Contains reports whether x lies in the interval
+func (a Interval[R]) Contains(x R) bool {
+	return a.Lo <= x && x <= a.Hi
+}
+
+// Encloses reports whether every value of b lies in a
+func (a Interval[R]) Encloses(b Interval[R]) bool {
+	return a.Lo <= b.Lo && b.Hi <= a.Hi
+}
+
+// Overlaps reports whether a and b share any value
+func (a Interval[R]) Overlaps(b Interval[R]) bool {
+	return a.Lo <= b.Hi && b.Lo <= a.Hi
+}
+
+// Intersect returns the values shared by a and b, and false if there are none
+func (a Interval[R]) Intersect(b Interval[R]) (Interval[R], bool) {
+	if !a.Overlaps(b) {
+		return Interval[R]{}, false
+	}
+	return Interval[R]{max(a.Lo, b.Lo), min(a.Hi, b.Hi)}, true
+}
+
+// Hull returns the smallest interval enclosing a and b
+func (a Interval[R]) Hull(b Interval[R]) Interval[R] {
+	return Interval[R]{min(a.Lo, b.Lo), max(a.Hi, b.Hi)}
+}
+
+// Neg returns -a
+func (a Interval[R]) Neg() Interval[R] {
+	if tools.IsInt[R]() {
+		return Interval[R]{satSub(0, a.Hi), satSub(0, a.Lo)}
+	}
+	return Interval[R]{-a.Hi, -a.Lo}
+}
+
+// Add returns a + b
+func (a Interval[R]) Add(b Interval[R]) Interval[R] {
+	if tools.IsInt[R]() {
+		return Interval[R]{satAdd(a.Lo, b.Lo), satAdd(a.Hi, b.Hi)}
+	}
+	lo, _ := addBounds(float64(a.Lo), float64(b.Lo))
+	_, hi := addBounds(float64(a.Hi), float64(b.Hi))
+	return outward[R](lo, hi, 0)
+}
+
+// Sub returns a - b
+func (a Interval[R]) Sub(b Interval[R]) Interval[R] {
+	if tools.IsInt[R]() {
+		return Interval[R]{satSub(a.Lo, b.Hi), satSub(a.Hi, b.Lo)}
+	}
+	lo, _ := addBounds(float64(a.Lo), -float64(b.Hi))
+	_, hi := addBounds(float64(a.Hi), -float64(b.Lo))
+	return outward[R](lo, hi, 0)
+}
+
+// Mul returns a × b
+func (a Interval[R]) Mul(b Interval[R]) Interval[R] {
+	if tools.IsInt[R]() {
+		ps := [4]R{satMul(a.Lo, b.Lo), satMul(a.Lo, b.Hi), satMul(a.Hi, b.Lo), satMul(a.Hi, b.Hi)}
+		return Interval[R]{min(ps[0], ps[1], ps[2], ps[3]), max(ps[0], ps[1], ps[2], ps[3])}
+	}
+	lo, hi := extremes(mulBounds, a, b)
+	return outward[R](lo, hi, 0)
+}
+
+// Div returns a ÷ b, or an ErrDivByZero if b contains zero
+func (a Interval[R]) Div(b Interval[R]) (Interval[R], error) {
+	if b.Contains(0) {
+		return Interval[R]{}, fmt.Errorf("oprs.interval: %v / %v: %w", a, b, ErrDivByZero)
+	}
+	if tools.IsInt[R]() {
+		qs := [4][2]R{}
+		for i, p := range [4][2]R{{a.Lo, b.Lo}, {a.Lo, b.Hi}, {a.Hi, b.Lo}, {a.Hi, b.Hi}} {
+			qs[i] = [2]R{floorDiv(p[0], p[1]), ceilDiv(p[0], p[1])}
+		}
+		return Interval[R]{
+			min(qs[0][0], qs[1][0], qs[2][0], qs[3][0]),
+			max(qs[0][1], qs[1][1], qs[2][1], qs[3][1]),
+		}, nil
+	}
+	lo, hi := extremes(divBounds, a, b)
+	return outward[R](lo, hi, 0), nil
+}
+
+// DivSplit returns the set {x/y : x in a, y in b}, even if b contains zero
+// It is empty if b is [0, 0], and may be two disjoint, unbounded intervals
+// otherwise, eg [1, 2] / [-1, 1] is [-Inf, -1] ∪ [1, +Inf]
+func (a Interval[R]) DivSplit(b Interval[R]) []Interval[R] {
+	if !b.Contains(0) {
+		q, _ := a.Div(b)
+		return []Interval[R]{q}
+	}
+	all := Entire[R]()
+	switch {
+	case b.Lo == 0 && b.Hi == 0:
+		return nil
+	case a.Contains(0):
+		return []Interval[R]{all}
+	}
+	// a lies to one side of zero, so its end nearest zero bounds the quotients
+	near := a.Lo
+	if a.Hi < 0 {
+		near = a.Hi
+	}
+	var out []Interval[R]
+	if b.Lo < 0 {
+		q, _ := Point(near).Div(Point(b.Lo))
+		if near > 0 {
+			out = append(out, Interval[R]{all.Lo, q.Hi})
+		} else {
+			out = append(out, Interval[R]{q.Lo, all.Hi})
+		}
+	}
+	if b.Hi > 0 {
+		q, _ := Point(near).Div(Point(b.Hi))
+		if near > 0 {
+			out = append(out, Interval[R]{q.Lo, all.Hi})
+		} else {
+			out = append(out, Interval[R]{all.Lo, q.Hi})
+		}
+	}
+	if len(out) == 2 && out[1].Lo < out[0].Lo {
+		out[0], out[1] = out[1], out[0]
+	}
+	return out
+}
+
+// IntervalExp returns the interval of e^x for x in a
+func IntervalExp[R rules.Real](a Interval[R]) Interval[R] {
+	lo, hi := widen(math.Exp(float64(a.Lo)), math.Exp(float64(a.Hi)), 2)
+	return outward[R](max(lo, 0), hi, 0)
+}
+
+// IntervalLog returns the interval of ln(x) for the positive x in a
+// It reports an ErrDomain if a has no positive values
+func IntervalLog[R rules.Real](a Interval[R]) (Interval[R], error) {
+	if !(a.Hi > 0) {
+		return Interval[R]{}, fmt.Errorf("oprs.interval: log %v: %w", a, ErrDomain)
+	}
+	return outward[R](math.Log(float64(max(a.Lo, 0))), math.Log(float64(a.Hi)), 2), nil
+}
+
+// IntervalSqrt returns the interval of √x for the non-negative x in a
+// It reports an ErrDomain if a has no non-negative values
+func IntervalSqrt[R rules.Real](a Interval[R]) (Interval[R], error) {
+	if !(a.Hi >= 0) {
+		return Interval[R]{}, fmt.Errorf("oprs.interval: sqrt %v: %w", a, ErrDomain)
+	}
+	lo, hi := widen(math.Sqrt(float64(max(a.Lo, 0))), math.Sqrt(float64(a.Hi)), 1)
+	return outward[R](max(lo, 0), hi, 0), nil
+}
+
+// IntervalSin returns the interval of sin(x) for x in a, including any peaks or troughs inside it
+func IntervalSin[R rules.Real](a Interval[R]) Interval[R] {
+	return periodic(math.Sin, math.Pi/2, a)
+}
+
+// IntervalCos returns the interval of cos(x) for x in a, including any peaks or troughs inside it
+func IntervalCos[R rules.Real](a Interval[R]) Interval[R] {
+	return periodic(math.Cos, 0, a)
+}
+
+// IntervalTan returns the interval of tan(x) for x in a
+// It is Entire if a contains one of tan's poles
+func IntervalTan[R rules.Real](a Interval[R]) Interval[R] {
+	lo, hi := float64(a.Lo), float64(a.Hi)
+	if hi-lo >= math.Pi || containsPhase(lo, hi, math.Pi/2, math.Pi) {
+		return Entire[R]()
+	}
+	return outward[R](math.Tan(lo), math.Tan(hi), 2)
+}
+
+// periodic bounds a sinusoid f, with period 2π and a peak at peak, over a
+func periodic[R rules.Real](f func(float64) float64, peak float64, a Interval[R]) Interval[R] {
+	lo, hi := float64(a.Lo), float64(a.Hi)
+	if hi-lo >= 2*math.Pi || math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+		return outward[R](-1, 1, 0)
+	}
+	flo, fhi := f(lo), f(hi)
+	outLo, outHi := min(flo, fhi), max(flo, fhi)
+	if containsPhase(lo, hi, peak, 2*math.Pi) {
+		outHi = 1
+	}
+	if containsPhase(lo, hi, peak+math.Pi, 2*math.Pi) {
+		outLo = -1
+	}
+	outLo, outHi = widen(outLo, outHi, 2)
+	return outward[R](max(outLo, -1), min(outHi, 1), 0)
+}
+
+// containsPhase reports whether [lo, hi] contains phase + k*period for some integer k
+func containsPhase(lo, hi, phase, period float64) bool {
+	k := math.Ceil((lo - phase) / period)
+	return phase+k*period <= hi
+}
+
+// The bounds functions below return the floating point numbers either side of the
+// exact result of an operation, or the result itself if it is exact
+// The rounding error is found exactly by TwoSum or a fused multiply-add, unless
+// the result overflows or underflows, in which case both sides are widened
+
+// addBounds bounds x + y
+func addBounds(x, y float64) (lo, hi float64) {
+	s := x + y
+	t := s - x
+	return bracket(s, (x-(s-t))+(y-t), false)
+}
+
+// mulBounds bounds x × y, taking 0 × ±Inf as 0
+func mulBounds(x, y float64) (lo, hi float64) {
+	if x == 0 || y == 0 {
+		return 0, 0
+	}
+	p := x * y
+	return bracket(p, math.FMA(x, y, -p), math.Abs(p) < 0x1p-1022)
+}
+
+// divBounds bounds x ÷ y
+func divBounds(x, y float64) (lo, hi float64) {
+	q := x / y
+	r := math.FMA(-q, y, x)
+	if y < 0 {
+		r = -r
+	}
+	return bracket(q, r, x != 0 && math.Abs(q) < 0x1p-1022)
+}
+
+// bracket returns the neighbours of a rounded result v on the side of its error,
+// or on both sides if the error is unknown or tiny is set
+func bracket(v, err float64, tiny bool) (lo, hi float64) {
+	switch {
+	case math.IsNaN(v):
+		return v, v
+	case tiny || math.IsNaN(err):
+		return widen(v, v, 1)
+	case err < 0:
+		return Nextafter(v, math.Inf(-1)), v
+	case err > 0:
+		return v, Nextafter(v, math.Inf(1))
+	}
+	return v, v
+}
+
+// extremes bounds op over each pair of ends of a and b, returning the smallest lower
+// bound and the largest upper bound
+func extremes[R rules.Real](op func(x, y float64) (lo, hi float64), a, b Interval[R]) (lo, hi float64) {
+	al, ah, bl, bh := float64(a.Lo), float64(a.Hi), float64(b.Lo), float64(b.Hi)
+	l1, h1 := op(al, bl)
+	l2, h2 := op(al, bh)
+	l3, h3 := op(ah, bl)
+	l4, h4 := op(ah, bh)
+	return min(l1, l2, l3, l4), max(h1, h2, h3, h4)
+}
+
+// outward rounds [lo, hi] outwards to the nearest enclosing interval of R, after
+// first widening it by the given number of float64 ulps, to cover rounding error
+func outward[R rules.Real](lo, hi float64, ulps int) Interval[R] {
+	lo, hi = widen(lo, hi, ulps)
+	return Interval[R]{roundTo[R](lo, math.Inf(-1)), roundTo[R](hi, math.Inf(1))}
+}
+
+// widen moves lo and hi apart by the given number of ulps
+func widen(lo, hi float64, ulps int) (float64, float64) {
+	for i := 0; i < ulps; i++ {
+		lo, hi = Nextafter(lo, math.Inf(-1)), Nextafter(hi, math.Inf(1))
+	}
+	return lo, hi
+}
+
+// roundTo converts x to R, rounding it in the direction of dir
+// integer types saturate at their bounds
+func roundTo[R rules.Real](x, dir float64) R {
+	var zero R
+	switch {
+	case math.IsNaN(x):
+		return R(x)
+	case !tools.IsInt[R]() && unsafe.Sizeof(zero) == 4:
+		f := float32(x)
+		if float64(f) != x && (float64(f) > x) == (dir < 0) {
+			f = Nextafter32(f, float32(dir))
+		}
+		return R(f)
+	case !tools.IsInt[R]():
+		return R(x)
+	}
+	if dir < 0 {
+		x = math.Floor(x)
+	} else {
+		x = math.Ceil(x)
+	}
+	lo, hi := tools.IntBounds[R]()
+	switch {
+	case x <= float64(lo):
+		return lo
+	case x >= float64(hi):
+		return hi
+	}
+	return R(x)
+}
+
+// satAdd returns x + y, saturating at the bounds of the integer type R
+func satAdd[R rules.Real](x, y R) R {
+	s := x + y
+	switch lo, hi := tools.IntBounds[R](); {
+	case y > 0 && s < x:
+		return hi
+	case y < 0 && s > x:
+		return lo
+	}
+	return s
+}
+
+// satSub returns x - y, saturating at the bounds of the integer type R
+func satSub[R rules.Real](x, y R) R {
+	d := x - y
+	switch lo, hi := tools.IntBounds[R](); {
+	case y > 0 && d > x:
+		return lo
+	case y < 0 && d < x:
+		return hi
+	}
+	return d
+}
+
+// satMul returns x × y, saturating at the bounds of the integer type R
+func satMul[R rules.Real](x, y R) R {
+	p := x * y
+	lo, hi := tools.IntBounds[R]()
+	// lo × -1 is the one overflow that division cannot detect, as lo / -1 is lo
+	if x != 0 && (p/x != y || lo < 0 && x+1 == 0 && y == lo) {
+		if (x < 0) != (y < 0) {
+			return lo
+		}
+		return hi
+	}
+	return p
+}
+
+// floorDiv divides integers, rounding towards -Inf
+// lo / -1 saturates at hi
+func floorDiv[R rules.Real](x, y R) R {
+	if lo, hi := tools.IntBounds[R](); lo < 0 && x == lo && y+1 == 0 {
+		return hi
+	}
+	q := x / y
+	if r := x - q*y; r != 0 && (r < 0) != (y < 0) {
+		q--
+	}
+	return q
+}
+
+// ceilDiv divides integers, rounding towards +Inf
+// lo / -1 saturates at hi
+func ceilDiv[R rules.Real](x, y R) R {
+	if lo, hi := tools.IntBounds[R](); lo < 0 && x == lo && y+1 == 0 {
+		return hi
+	}
+	q := x / y
+	if r := x - q*y; r != 0 && (r < 0) == (y < 0) {
+		q++
+	}
+	return q
+}
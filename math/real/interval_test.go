@@ -0,0 +1,224 @@
+package real
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randInterval(scale float64) Interval[float64] {
+	x, y := scale*(2*rand.Float64()-1), scale*(2*rand.Float64()-1)
+	return Interval[float64]{min(x, y), max(x, y)}
+}
+
+func TestNewInterval(t *testing.T) {
+	a, err := NewInterval(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, Interval[int]{1, 2}, a)
+	_, err = NewInterval(2, 1)
+	assert.ErrorIs(t, err, ErrInterval)
+	_, err = NewInterval(math.NaN(), 1)
+	assert.ErrorIs(t, err, ErrInterval)
+	assert.Equal(t, "[1, 2]", a.String())
+	assert.Equal(t, Interval[int8]{-128, 127}, Entire[int8]())
+	assert.Equal(t, Interval[uint8]{0, 255}, Entire[uint8]())
+	assert.Equal(t, Interval[float64]{math.Inf(-1), math.Inf(1)}, Entire[float64]())
+}
+
+func TestIntervalSets(t *testing.T) {
+	a, b, c := Interval[int]{0, 4}, Interval[int]{2, 6}, Interval[int]{5, 6}
+	assert.True(t, a.Contains(0))
+	assert.True(t, a.Contains(4))
+	assert.False(t, a.Contains(5))
+	assert.True(t, a.Encloses(Interval[int]{1, 3}))
+	assert.False(t, a.Encloses(b))
+	assert.True(t, a.Overlaps(b))
+	assert.False(t, a.Overlaps(c))
+
+	got, ok := a.Intersect(b)
+	assert.True(t, ok)
+	assert.Equal(t, Interval[int]{2, 4}, got)
+	_, ok = a.Intersect(c)
+	assert.False(t, ok)
+	assert.Equal(t, Interval[int]{0, 6}, a.Hull(c))
+	assert.Equal(t, 4, a.Width())
+	assert.Equal(t, 2, a.Mid())
+}
+
+func TestIntervalIntArith(t *testing.T) {
+	a, b := Interval[int]{-2, 3}, Interval[int]{4, 5}
+	assert.Equal(t, Interval[int]{2, 8}, a.Add(b))
+	assert.Equal(t, Interval[int]{-7, -1}, a.Sub(b))
+	assert.Equal(t, Interval[int]{-10, 15}, a.Mul(b))
+	assert.Equal(t, Interval[int]{-3, 2}, a.Neg())
+
+	q, err := a.Div(b)
+	assert.NoError(t, err)
+	// -2/4 floors to -1, 3/4 ceils to 1
+	assert.Equal(t, Interval[int]{-1, 1}, q)
+	q, err = Interval[int]{7, 9}.Div(Interval[int]{-3, -2})
+	assert.NoError(t, err)
+	assert.Equal(t, Interval[int]{-5, -2}, q)
+	_, err = b.Div(a)
+	assert.ErrorIs(t, err, ErrDivByZero)
+}
+
+func TestIntervalIntOverflow(t *testing.T) {
+	big := Interval[int8]{100, 120}
+	assert.Equal(t, Interval[int8]{127, 127}, big.Add(big))
+	assert.Equal(t, Interval[int8]{127, 127}, big.Mul(big))
+	assert.Equal(t, Interval[int8]{-128, -128}, big.Neg().Sub(big))
+	assert.Equal(t, Interval[int8]{-128, -100}, big.Neg().Mul(Interval[int8]{1, 2}))
+	assert.Equal(t, Interval[int8]{-127, 127}, Interval[int8]{-128, 127}.Neg())
+	q, err := Point[int8](-128).Div(Point[int8](-1))
+	assert.NoError(t, err)
+	assert.Equal(t, Point[int8](127), q)
+	assert.Equal(t, Interval[uint8]{0, 5}, Interval[uint8]{0, 10}.Sub(Point[uint8](5)))
+
+	// every exact result, clamped to int8, lies in the interval
+	clamp := func(x int) int8 { return int8(min(max(x, -128), 127)) }
+	for i := 0; i < nTests; i++ {
+		a, b := randInt8Interval(), randInt8Interval()
+		sum, diff, prod := a.Add(b), a.Sub(b), a.Mul(b)
+		quo, qerr := a.Div(b)
+		for x := int(a.Lo); x <= int(a.Hi); x++ {
+			for y := int(b.Lo); y <= int(b.Hi); y++ {
+				assert.True(t, sum.Contains(clamp(x+y)), "%v + %v", a, b)
+				assert.True(t, diff.Contains(clamp(x-y)), "%v - %v", a, b)
+				assert.True(t, prod.Contains(clamp(x*y)), "%v × %v", a, b)
+				if qerr == nil {
+					assert.True(t, quo.Contains(clamp(x/y)), "%v / %v", a, b)
+				}
+			}
+		}
+	}
+}
+
+func randInt8Interval() Interval[int8] {
+	x, y := int8(rand.Intn(256)-128), int8(rand.Intn(256)-128)
+	return Interval[int8]{min(x, y), max(x, y)}
+}
+
+func TestIntervalFloatArith(t *testing.T) {
+	a, b := Point(0.1), Point(0.2)
+	sum := a.Add(b)
+	assert.True(t, sum.Contains(0.1+0.2))
+	assert.Less(t, sum.Lo, sum.Hi)
+	assert.True(t, sum.Encloses(Point(0.30000000000000004)))
+
+	var f32 Interval[float32] = Point[float32](0.1).Mul(Point[float32](3))
+	assert.LessOrEqual(t, float64(f32.Lo), 0.1*3)
+	assert.GreaterOrEqual(t, float64(f32.Hi), 0.1*3)
+	assert.Less(t, f32.Lo, f32.Hi)
+
+	inf := Entire[float64]()
+	assert.Equal(t, inf, inf.Mul(Interval[float64]{-1, 1}))
+	assert.Equal(t, Interval[float64]{0, 0}, inf.Mul(Point(0.0)))
+
+	for i := 0; i < nTests; i++ {
+		a, b := randInterval(nMax), randInterval(nMax)
+		for j := 0; j < nItems; j++ {
+			x := a.Lo + rand.Float64()*a.Width()
+			y := b.Lo + rand.Float64()*b.Width()
+			assert.True(t, a.Add(b).Contains(x+y))
+			assert.True(t, a.Sub(b).Contains(x-y))
+			assert.True(t, a.Mul(b).Contains(x*y))
+			if q, err := a.Div(b); err == nil {
+				assert.True(t, q.Contains(x/y))
+			} else {
+				assert.ErrorIs(t, err, ErrDivByZero)
+				assert.True(t, b.Contains(0))
+			}
+		}
+	}
+}
+
+func TestIntervalDivSplit(t *testing.T) {
+	inf := math.Inf(1)
+	for _, test := range []struct {
+		a, b Interval[float64]
+		want []Interval[float64]
+	}{
+		{Interval[float64]{1, 2}, Interval[float64]{0, 0}, nil},
+		{Interval[float64]{-1, 2}, Interval[float64]{-1, 1}, []Interval[float64]{{-inf, inf}}},
+		{Interval[float64]{1, 2}, Interval[float64]{-1, 1}, []Interval[float64]{{-inf, -1}, {1, inf}}},
+		{Interval[float64]{-2, -1}, Interval[float64]{-1, 1}, []Interval[float64]{{-inf, -1}, {1, inf}}},
+		{Interval[float64]{1, 2}, Interval[float64]{0, 4}, []Interval[float64]{{0.25, inf}}},
+		{Interval[float64]{1, 2}, Interval[float64]{-4, 0}, []Interval[float64]{{-inf, -0.25}}},
+		{Interval[float64]{-2, -1}, Interval[float64]{0, 4}, []Interval[float64]{{-inf, -0.25}}},
+	} {
+		got := test.a.DivSplit(test.b)
+		if !assert.Len(t, got, len(test.want), "%v / %v", test.a, test.b) {
+			continue
+		}
+		for i, want := range test.want {
+			// finite ends are rounded outwards
+			assert.True(t, got[i].Encloses(want), "%v / %v: %v", test.a, test.b, got)
+			assert.InDelta(t, want.Lo, got[i].Lo, 1e-15)
+			assert.InDelta(t, want.Hi, got[i].Hi, 1e-15)
+		}
+	}
+	q, _ := Interval[float64]{1, 2}.Div(Interval[float64]{2, 4})
+	assert.Equal(t, []Interval[float64]{q}, Interval[float64]{1, 2}.DivSplit(Interval[float64]{2, 4}))
+	assert.Equal(t, []Interval[int8]{{-128, 0}, {0, 127}}, Interval[int8]{1, 2}.DivSplit(Interval[int8]{-2, 2}))
+}
+
+func TestIntervalMonotone(t *testing.T) {
+	e := IntervalExp(Interval[float64]{0, 1})
+	assert.True(t, e.Contains(1))
+	assert.True(t, e.Contains(math.E))
+	assert.InDelta(t, 1, e.Lo, 1e-15)
+	assert.Equal(t, Interval[int]{0, 3}, IntervalExp(Interval[int]{0, 1}))
+	assert.Equal(t, 0.0, IntervalExp(Interval[float64]{math.Inf(-1), 0}).Lo)
+
+	l, err := IntervalLog(Interval[float64]{1, math.E})
+	assert.NoError(t, err)
+	assert.True(t, l.Contains(0))
+	assert.True(t, l.Contains(1))
+	l, err = IntervalLog(Interval[float64]{-1, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, math.Inf(-1), l.Lo)
+	_, err = IntervalLog(Interval[float64]{-2, 0})
+	assert.ErrorIs(t, err, ErrDomain)
+
+	s, err := IntervalSqrt(Interval[float64]{-1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, s.Lo)
+	assert.True(t, s.Contains(math.Sqrt2))
+	si, err := IntervalSqrt(Interval[int]{4, 10})
+	assert.NoError(t, err)
+	assert.Equal(t, Interval[int]{1, 4}, si)
+	_, err = IntervalSqrt(Interval[float64]{-2, -1})
+	assert.ErrorIs(t, err, ErrDomain)
+}
+
+func TestIntervalTrig(t *testing.T) {
+	// [0, π] contains sin's peak but neither trough
+	s := IntervalSin(Interval[float64]{0, math.Pi})
+	assert.Equal(t, 1.0, s.Hi)
+	assert.InDelta(t, 0, s.Lo, 1e-15)
+	assert.Equal(t, Interval[float64]{-1, 1}, IntervalSin(Interval[float64]{0, 7}))
+	assert.Equal(t, Interval[float64]{-1, 1}, IntervalCos(Interval[float64]{-10, 10}))
+	c := IntervalCos(Interval[float64]{1, 4})
+	assert.Equal(t, -1.0, c.Lo)
+	assert.InDelta(t, math.Cos(1), c.Hi, 1e-15)
+	assert.Equal(t, Interval[int]{-1, 1}, IntervalSin(Interval[int]{0, 2}))
+
+	tn := IntervalTan(Interval[float64]{-1, 1})
+	assert.True(t, tn.Contains(math.Tan(1)))
+	assert.True(t, tn.Contains(math.Tan(-1)))
+	assert.Equal(t, Entire[float64](), IntervalTan(Interval[float64]{1, 2}))
+
+	for i := 0; i < nTests; i++ {
+		a := randInterval(nMax)
+		sin, cos, tan := IntervalSin(a), IntervalCos(a), IntervalTan(a)
+		for j := 0; j < nItems; j++ {
+			x := a.Lo + rand.Float64()*a.Width()
+			assert.True(t, sin.Contains(math.Sin(x)), "sin %v: %v", x, sin)
+			assert.True(t, cos.Contains(math.Cos(x)), "cos %v: %v", x, cos)
+			assert.True(t, tan.Contains(math.Tan(x)), "tan %v: %v", x, tan)
+		}
+	}
+}
@@ -0,0 +1,388 @@
+package real
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/kendfss/oprs"
+	"github.com/kendfss/oprs/internal/tools"
+	"github.com/kendfss/rules"
+)
+
+// segmentSize is the number of candidates sieved at a time by PrimesSeq
+const segmentSize = 1 << 15
+
+// sieveLimit bounds the base primes PrimesSeq sieves with, and so its memory
+// Candidates they leave beyond sieveLimit² are confirmed by Miller-Rabin instead
+const sieveLimit = 1 << 20
+
+// MaxPrimePi is the largest argument PrimePi accepts
+// Its tables take 16·√n bytes, 16MiB at this limit
+const MaxPrimePi = 1 << 40
+
+// smallPrimes are used for trial division and as Miller-Rabin witnesses
+// testing against all of them is deterministic for every 64-bit integer
+var smallPrimes = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// PrimePower is a prime factor together with its multiplicity
+type PrimePower[I rules.Int] struct {
+	Prime I
+	Exp   int
+}
+
+// Eratosthenes' prime sieve
+// returns the primes below r
+func Eratosthenes[R rules.Real](r R) (out []R) {
+	for e := range EratosthenesSeq(r) {
+		out = append(out, e)
+	}
+	return out
+}
+
+// Eratosthenesch executes Eratosthenes' prime sieve on a new goroutine
+// The channel is closed once the sieve is exhausted or the context is done
+func Eratosthenesch[R rules.Real](ctx context.Context, r R) <-chan R {
+	return tools.Chan(ctx, EratosthenesSeq(r))
+}
+
+// EratosthenesSeq iterates over the primes below r via a segmented sieve
+// Unlike Eratosthenesch, it does not start a goroutine, so breaking out of it early is free
+func EratosthenesSeq[R rules.Real](r R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		if r <= 2 {
+			return
+		}
+		hi := uint64(math.Floor(float64(r)))
+		if tools.IsInt[R]() {
+			hi = uint64(r)
+		}
+		for p := range primesSeq(2, hi) {
+			if !yield(R(p)) {
+				return
+			}
+		}
+	}
+}
+
+// Primes returns the primes in the range [lo, hi)
+// hi is excluded, so a prime that is the largest value of I, such as 127 for int8, is never returned
+func Primes[I rules.Int](lo, hi I) (out []I) {
+	for p := range PrimesSeq(lo, hi) {
+		out = append(out, p)
+	}
+	return out
+}
+
+// PrimesSeq iterates over the primes in the range [lo, hi) via a segmented sieve
+// hi is excluded, so a prime that is the largest value of I, such as 127 for int8, is never yielded
+// memory use is proportional to the square root of hi, up to a fixed bound, rather than to the
+// width of the range. Beyond 2^40, the sieve's survivors are confirmed as IsPrime does
+func PrimesSeq[I rules.Int](lo, hi I) iter.Seq[I] {
+	return func(yield func(I) bool) {
+		if hi <= 2 || hi <= lo {
+			return
+		}
+		if lo < 2 {
+			lo = 2
+		}
+		// 2 <= lo <= p < hi, so the conversions to and from uint64 are exact
+		for p := range primesSeq(uint64(lo), uint64(hi)) {
+			if !yield(I(p)) {
+				return
+			}
+		}
+	}
+}
+
+// primesSeq sieves [lo, hi) one segment at a time
+func primesSeq(lo, hi uint64) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		root := isqrt(hi - 1)
+		base := simpleSieve(min(root, sieveLimit))
+		exact := root <= sieveLimit
+		composite := make([]bool, segmentSize)
+		for start := lo; start < hi; {
+			end := hi
+			if hi-start > segmentSize {
+				end = start + segmentSize
+			}
+			seg := composite[:end-start]
+			clear(seg)
+			for _, p := range base {
+				if p*p >= end {
+					break
+				}
+				// the first multiple of p in the segment, computed without overflowing near 2^64
+				first := start
+				if r := start % p; r != 0 {
+					if first += p - r; first < start {
+						continue
+					}
+				}
+				for m := max(p*p, first); m < end; m += p {
+					seg[m-start] = true
+					if m > math.MaxUint64-p {
+						break
+					}
+				}
+			}
+			for i, c := range seg {
+				if n := start + uint64(i); !c && (exact || isPrime(n)) && !yield(n) {
+					return
+				}
+			}
+			start = end
+		}
+	}
+}
+
+// simpleSieve returns the primes up to and including n
+func simpleSieve(n uint64) (out []uint64) {
+	composite := make([]bool, n+1)
+	for i := uint64(2); i <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		out = append(out, i)
+		for m := i * i; m <= n; m += i {
+			composite[m] = true
+		}
+	}
+	return out
+}
+
+// IsPrime checks if a real number is prime
+// it uses a deterministic Miller-Rabin test, so values beyond 64 bits are never prime
+func IsPrime[R rules.Real](r R) bool {
+	if tools.IsInt[R]() {
+		return r >= 2 && isPrime(uint64(r))
+	}
+	f := float64(r)
+	if f < 2 || f != math.Trunc(f) || f >= math.MaxUint64 {
+		return false
+	}
+	return isPrime(uint64(f))
+}
+
+func isPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range smallPrimes {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+	d, s := n-1, 0
+	for d%2 == 0 {
+		d /= 2
+		s++
+	}
+witnesses:
+	for _, a := range smallPrimes {
+		x := powmod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		for i := 1; i < s; i++ {
+			if x = mulmod(x, x, n); x == n-1 {
+				continue witnesses
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Factorize returns the prime factorization of the magnitude of n in ascending order
+// 0 and ±1 have no prime factors
+func Factorize[I rules.Int](n I) []PrimePower[I] {
+	u := uint64(n)
+	if n < 0 {
+		u = -u
+	}
+	factors := factorize(u, nil)
+	sort.Slice(factors, func(i, j int) bool { return factors[i] < factors[j] })
+	var out []PrimePower[I]
+	for _, f := range factors {
+		if len(out) > 0 && out[len(out)-1].Prime == I(f) {
+			out[len(out)-1].Exp++
+		} else {
+			out = append(out, PrimePower[I]{I(f), 1})
+		}
+	}
+	return out
+}
+
+func factorize(n uint64, out []uint64) []uint64 {
+	if n < 2 {
+		return out
+	}
+	for _, p := range smallPrimes {
+		for n%p == 0 {
+			out = append(out, p)
+			n /= p
+		}
+	}
+	if n == 1 {
+		return out
+	}
+	if isPrime(n) {
+		return append(out, n)
+	}
+	d := rho(n)
+	return factorize(n/d, factorize(d, out))
+}
+
+// rho finds a non-trivial factor of an odd composite via Brent's variant of Pollard's rho
+func rho(n uint64) uint64 {
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 { return addmod(mulmod(x, x, n), c, n) }
+		x, y, ys, q, g := uint64(0), uint64(2), uint64(0), uint64(1), uint64(1)
+		for r := 1; g == 1; r *= 2 {
+			x = y
+			for i := 0; i < r; i++ {
+				y = f(y)
+			}
+			for k := 0; k < r && g == 1; k += 128 {
+				ys = y
+				for i := 0; i < min(128, r-k); i++ {
+					y = f(y)
+					q = mulmod(q, Diff(x, y), n)
+				}
+				g = gcd(q, n)
+			}
+		}
+		if g == n {
+			for g = 1; g == 1; {
+				ys = f(ys)
+				g = gcd(Diff(x, ys), n)
+			}
+		}
+		if g != n {
+			return g
+		}
+	}
+}
+
+// GPF returns the largest prime factor of the magnitude of n
+// or 0 if it has none
+func GPF[I rules.Int](n I) I {
+	factors := Factorize(n)
+	if len(factors) == 0 {
+		return 0
+	}
+	return factors[len(factors)-1].Prime
+}
+
+// PrimePi counts the primes less than or equal to n
+// it uses the Lucy_Hedgehog method, which runs in roughly O(n^(3/4)) time and O(√n) memory,
+// and reports an oprs.ErrOverflow if n is beyond MaxPrimePi
+func PrimePi[I rules.Int](n I) (I, error) {
+	switch {
+	case n < 2:
+		return 0, nil
+	case uint64(n) > MaxPrimePi:
+		return 0, fmt.Errorf("oprs.primes: PrimePi(%v) is beyond MaxPrimePi: %w", n, oprs.ErrOverflow)
+	}
+	return I(primePi(uint64(n))), nil
+}
+
+func primePi(n uint64) uint64 {
+	r := isqrt(n)
+	small := make([]uint64, r+1) // small[i] counts primes <= i
+	large := make([]uint64, r+1) // large[i] counts primes <= n/i
+	for i := uint64(1); i <= r; i++ {
+		small[i] = i - 1
+		large[i] = n/i - 1
+	}
+	for p := uint64(2); p <= r; p++ {
+		if small[p] == small[p-1] {
+			continue
+		}
+		count, square := small[p-1], p*p
+		for i := uint64(1); i <= r && i <= n/square; i++ {
+			if d := i * p; d <= r {
+				large[i] -= large[d] - count
+			} else {
+				large[i] -= small[n/d] - count
+			}
+		}
+		for i := r; i >= square; i-- {
+			small[i] -= small[i/p] - count
+		}
+	}
+	return large[1]
+}
+
+// NthPrime returns the nth prime, counting from NthPrime(1) == 2
+// it reports an ErrDomain if n < 1, and an oprs.ErrOverflow if the prime does not fit in I
+func NthPrime[I rules.Int](n I) (I, error) {
+	if n < 1 {
+		return 0, fmt.Errorf("oprs.primes: prime #%v: %w", n, ErrDomain)
+	}
+	bound := uint64(15)
+	if f := float64(n); n >= 6 {
+		// the nth prime is below n(ln n + ln ln n)
+		est := f * (math.Log(f) + math.Log(math.Log(f)))
+		if est >= math.MaxUint64 {
+			return 0, fmt.Errorf("oprs.primes: prime #%v does not fit in %T: %w", n, n, oprs.ErrOverflow)
+		}
+		bound = uint64(est) + 1
+	}
+	count := uint64(0)
+	_, top := tools.IntBounds[I]()
+	for p := range primesSeq(2, bound+1) {
+		if count++; count == uint64(n) {
+			if p > uint64(top) {
+				return 0, fmt.Errorf("oprs.primes: prime #%v is %d, which does not fit in %T: %w", n, p, n, oprs.ErrOverflow)
+			}
+			return I(p), nil
+		}
+	}
+	return 0, nil
+}
+
+// isqrt returns the floor of the square root of n
+func isqrt(n uint64) uint64 {
+	r := uint64(math.Sqrt(float64(n)))
+	for r*r > n || r > math.MaxUint32 {
+		r--
+	}
+	for (r+1)*(r+1) <= n && r < math.MaxUint32 {
+		r++
+	}
+	return r
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func mulmod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+func addmod(a, b, m uint64) uint64 {
+	sum, carry := bits.Add64(a, b, 0)
+	return bits.Rem64(carry, sum, m)
+}
+
+func powmod(base, exp, m uint64) uint64 {
+	out := uint64(1)
+	for base %= m; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			out = mulmod(out, base, m)
+		}
+		base = mulmod(base, base, m)
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package real
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kendfss/oprs"
+)
+
+func TestPrimes(t *testing.T) {
+	assert.Equal(t, []int{101, 103, 107, 109, 113}, Primes(100, 120))
+	assert.Equal(t, []float64{2, 3, 5, 7}, Eratosthenes(10.5))
+	assert.Equal(t, 78498, len(Primes(0, 1_000_000)))
+	assert.Equal(t, []uint64{1<<32 + 15}, Primes[uint64](1<<32+1, 1<<32+16))
+
+	// beyond sieveLimit², the sieve's survivors are confirmed by Miller-Rabin
+	assert.Equal(t, []uint64{18446744073709551521, 18446744073709551533, 18446744073709551557}, Primes[uint64](1<<64-100, 1<<64-1))
+	assert.Equal(t, []int64{1<<42 + 15}, Primes[int64](1<<42, 1<<42+64))
+	for _, p := range Primes[uint64](1<<40-1000, 1<<40+1000) {
+		assert.True(t, IsPrime(p), "%d", p)
+	}
+	assert.Len(t, Primes[uint64](1<<40-1000, 1<<40+1000), 62)
+}
+
+func TestIsPrime(t *testing.T) {
+	for _, p := range Primes(0, 10_000) {
+		assert.True(t, IsPrime(p), "%d", p)
+	}
+	assert.Equal(t, 1229, len(Primes(0, 10_000)))
+	assert.True(t, IsPrime(uint64(18446744073709551557)))
+	assert.True(t, IsPrime(int64(9223372036854775783)))
+	assert.False(t, IsPrime(uint64(3215031751))) // strong pseudoprime to bases 2, 3, 5 and 7
+	assert.False(t, IsPrime(int64(3825123056546413051)))
+	assert.False(t, IsPrime(7.5))
+	assert.True(t, IsPrime(7.0))
+	assert.False(t, IsPrime(-7))
+}
+
+func TestFactorize(t *testing.T) {
+	assert.Equal(t, []PrimePower[int]{{2, 3}, {3, 2}, {5, 1}}, Factorize(-360))
+	assert.Nil(t, Factorize(1))
+	assert.Equal(t, []PrimePower[uint64]{{4294967279, 1}, {4294967291, 1}}, Factorize(uint64(4294967279*4294967291)))
+	assert.Equal(t, []PrimePower[int64]{{2, 63}}, Factorize(int64(-1<<63)))
+	assert.Equal(t, []PrimePower[uint64]{{1000003, 2}}, Factorize(uint64(1000003*1000003)))
+	assert.Equal(t, uint64(4294967291), GPF(uint64(4294967279*4294967291)))
+	assert.Equal(t, int8(0), GPF(int8(1)))
+}
+
+func TestPrimePi(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 100, 1000, 12345} {
+		pi, err := PrimePi(n)
+		assert.NoError(t, err)
+		assert.Equal(t, len(Primes(0, n+1)), pi, "%d", n)
+	}
+	pi, err := PrimePi(int64(1e10))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(455052511), pi)
+	_, err = PrimePi(uint64(MaxPrimePi + 1))
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+}
+
+func TestNthPrime(t *testing.T) {
+	for n, want := range map[int]int{1: 2, 6: 13, 10_000: 104729} {
+		got, err := NthPrime(n)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, "%d", n)
+	}
+	_, err := NthPrime(0)
+	assert.ErrorIs(t, err, ErrDomain)
+	_, err = NthPrime(-3)
+	assert.ErrorIs(t, err, ErrDomain)
+
+	p, err := NthPrime(int8(31))
+	assert.NoError(t, err)
+	assert.Equal(t, int8(127), p)
+	_, err = NthPrime(int8(32))
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+	_, err = NthPrime(int8(40))
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+	p8, err := NthPrime(uint8(54))
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(251), p8)
+	_, err = NthPrime(uint8(60))
+	assert.ErrorIs(t, err, oprs.ErrOverflow)
+
+	assert.Equal(t, []int8{113}, Primes(int8(110), int8(127)))
+	assert.Equal(t, []uint8{241, 251}, Primes(uint8(240), uint8(255)))
+}
@@ -3,7 +3,6 @@ package real
 import (
 	"math"
 
-	"github.com/kendfss/oprs/internal/tools"
 	"github.com/kendfss/rules"
 )
 
@@ -160,55 +159,13 @@ func Diff[R rules.Real](a, b R) R {
 	return Abs(a - b)
 }
 
-// GCD returns the Greatest Common Divisor as per the Euclidean algorithm
+// GCD returns the non-negative Greatest Common Divisor as per the Euclidean algorithm
+// GCD(0, 0) is 0
 func GCD[I rules.Int](a, b I) I {
-	for a != b {
-		if a > b {
-			a -= b
-		} else {
-			b -= a
-		}
-	}
-	return a
-}
-
-// Eratosthenes' prime sieve
-func Eratosthenes[R rules.Real](r R) (out []R) {
-	return tools.Consume(Eratosthenesch(r))
-}
-
-// Eratosthenesch executes Eratosthenes' prime sieve (single use, non-blocking)
-func Eratosthenesch[R rules.Real](r R) chan R {
-	out := make(chan R)
-	go func() {
-		rack := tools.MustUpto(2, r, 1)
-		marked := []R{}
-		for _, e := range rack {
-			if !tools.Contains(marked, e) {
-				marked = append(marked, tools.FilterPred(func(e2 R) bool {
-					return Fmod(e2, e) == 0 && e2 > e
-				}, rack)...)
-				out <- e
-			}
-		}
-	}()
-	return out
-}
-
-// IsPrime checks if a real number is prime
-func IsPrime[R rules.Real](r R) bool {
-	for e := range Eratosthenesch(r + 1) {
-		if e == r {
-			return true
-		}
+	for b != 0 {
+		a, b = b, a%b
 	}
-	return false
-}
-
-// GPF returns the largest prime factor
-func GPF[I rules.Int](n I) I {
-	factors := tools.FilterPred(tools.Method(n, Divs[I]), Eratosthenes(n))
-	return factors[len(factors)-1]
+	return Abs(a)
 }
 
 func Sin[R rules.Real](r R) R {
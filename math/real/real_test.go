@@ -1,11 +1,14 @@
 package real
 
 import (
+	"context"
 	"math/rand"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/kendfss/oprs/internal/tools"
 	"github.com/kendfss/rules"
 )
 
@@ -46,3 +49,37 @@ func TestNeg(t *testing.T) {
 	negTester(t, complex128(9))
 	negTester(t, complex(10, 11))
 }
+
+func TestEratosthenesch(t *testing.T) {
+	want := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
+	before := runtime.NumGoroutine()
+
+	assert.Equal(t, want, tools.Consume(Eratosthenesch(context.Background(), 30)))
+	assert.True(t, tools.Settled(before), "exhausted sieve leaked a goroutine")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for e := range Eratosthenesch(ctx, 1000) {
+		if e > 10 {
+			break
+		}
+	}
+	cancel()
+	assert.True(t, tools.Settled(before), "abandoned sieve leaked a goroutine")
+
+	for i := 0; i < nTests; i++ {
+		assert.True(t, IsPrime(want[i]))
+		assert.False(t, IsPrime(want[i]*(i+2)))
+	}
+	assert.True(t, tools.Settled(before), "IsPrime leaked a goroutine")
+	assert.Equal(t, want, Eratosthenes(30))
+}
+
+func TestGCD(t *testing.T) {
+	assert.Equal(t, 6, GCD(12, 18))
+	assert.Equal(t, 6, GCD(-12, 18))
+	assert.Equal(t, 6, GCD(12, -18))
+	assert.Equal(t, 7, GCD(0, 7))
+	assert.Equal(t, 7, GCD(-7, 0))
+	assert.Equal(t, 0, GCD(0, 0))
+	assert.Equal(t, uint8(5), GCD[uint8](255, 5))
+}
@@ -0,0 +1,99 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/kendfss/rules"
+)
+
+// Accumulator computes running summaries of a stream with Welford's algorithm,
+// in constant memory and without the cancellation of the naive sum of squares
+// It is not safe for concurrent use: give each goroutine its own and Merge them
+// The zero value is empty and ready to use
+type Accumulator[R rules.Real] struct {
+	n        int
+	mean, m2 float64
+	min, max R
+}
+
+// Add includes values in the summaries
+func (a *Accumulator[R]) Add(xs ...R) {
+	for _, x := range xs {
+		if a.n == 0 || x < a.min {
+			a.min = x
+		}
+		if a.n == 0 || x > a.max {
+			a.max = x
+		}
+		a.n++
+		d := float64(x) - a.mean
+		a.mean += d / float64(a.n)
+		a.m2 += d * (float64(x) - a.mean)
+	}
+}
+
+// Merge includes the values summarised by another accumulator, as if they had been added to this one
+func (a *Accumulator[R]) Merge(b Accumulator[R]) {
+	switch {
+	case b.n == 0:
+		return
+	case a.n == 0:
+		*a = b
+		return
+	}
+	n := a.n + b.n
+	d := b.mean - a.mean
+	a.m2 += b.m2 + d*d*float64(a.n)*float64(b.n)/float64(n)
+	a.mean += d * float64(b.n) / float64(n)
+	a.n = n
+	a.min, a.max = min(a.min, b.min), max(a.max, b.max)
+}
+
+// Count returns the number of values added
+func (a *Accumulator[R]) Count() int {
+	return a.n
+}
+
+// Mean returns the arithmetic mean
+func (a *Accumulator[R]) Mean() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.mean
+}
+
+// Variance returns the population variance
+func (a *Accumulator[R]) Variance() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.m2 / float64(a.n)
+}
+
+// SampleVariance returns the unbiased sample variance
+func (a *Accumulator[R]) SampleVariance() float64 {
+	if a.n < 2 {
+		return math.NaN()
+	}
+	return a.m2 / float64(a.n-1)
+}
+
+// StdDev returns the population standard deviation
+func (a *Accumulator[R]) StdDev() float64 {
+	return math.Sqrt(a.Variance())
+}
+
+// SampleStdDev returns the sample standard deviation
+func (a *Accumulator[R]) SampleStdDev() float64 {
+	return math.Sqrt(a.SampleVariance())
+}
+
+// Min returns the smallest value added, or zero if there are none
+func (a *Accumulator[R]) Min() R {
+	return a.min
+}
+
+// Max returns the largest value added, or zero if there are none
+func (a *Accumulator[R]) Max() R {
+	return a.max
+}
@@ -0,0 +1,93 @@
+package stats
+
+import (
+	"math"
+	"slices"
+
+	"github.com/kendfss/rules"
+)
+
+// Histogram counts how many values fall in each of a run of adjacent bins
+// Bin i covers [Edges[i], Edges[i+1]), except the last, which also includes its upper edge
+type Histogram struct {
+	Edges  []float64 // len(Counts)+1 ascending bin edges
+	Counts []int
+}
+
+// NewHistogram sorts values into the given number of equally wide bins spanning [lo, hi]
+// values outside the range, and NaNs, are not counted
+func NewHistogram[R rules.Real](bins int, lo, hi float64, xs ...R) Histogram {
+	bins = max(bins, 1)
+	h := Histogram{Edges: make([]float64, bins+1), Counts: make([]int, bins)}
+	width := (hi - lo) / float64(bins)
+	for i := range h.Edges {
+		h.Edges[i] = lo + float64(i)*width
+	}
+	h.Edges[0], h.Edges[bins] = lo, hi
+	for _, x := range xs {
+		f := float64(x)
+		if !(f >= lo && f <= hi) {
+			continue
+		}
+		i := bins - 1
+		if width > 0 && !math.IsInf(width, 0) {
+			i = min(max(int((f-lo)/width), 0), bins-1)
+		}
+		h.Counts[i]++
+	}
+	return h
+}
+
+// Total returns the number of values counted by the histogram
+func (h Histogram) Total() (out int) {
+	for _, n := range h.Counts {
+		out += n
+	}
+	return out
+}
+
+// BinRule chooses a number of bins for a sample
+type BinRule func(xs []float64) int
+
+// Sturges is the BinRule ceil(log2(n)) + 1, which suits roughly normal data
+func Sturges(xs []float64) int {
+	return int(math.Ceil(math.Log2(float64(len(xs))))) + 1
+}
+
+// Scott is the BinRule that uses bins 3.49σn^(-1/3) wide
+func Scott(xs []float64) int {
+	return binsOfWidth(xs, 3.49*SampleStdDev(xs...)*math.Cbrt(1/float64(len(xs))))
+}
+
+// FreedmanDiaconis is the BinRule that uses bins 2·IQR·n^(-1/3) wide, which is robust to outliers
+func FreedmanDiaconis(xs []float64) int {
+	iqr := Quantile(0.75, Linear, xs...) - Quantile(0.25, Linear, xs...)
+	return binsOfWidth(xs, 2*iqr*math.Cbrt(1/float64(len(xs))))
+}
+
+// binsOfWidth counts the bins of the given width needed to span a sample,
+// at most one per value, so that an outlier cannot demand a huge number of them
+// it is 1 if the width is not positive
+func binsOfWidth(xs []float64, width float64) int {
+	if !(width > 0) {
+		return 1
+	}
+	bins := math.Ceil((slices.Max(xs) - slices.Min(xs)) / width)
+	return int(max(min(bins, float64(len(xs))), 1))
+}
+
+// AutoHistogram sorts values into bins spanning their range, as many as the rule chooses,
+// but no more than there are values
+// NaNs and infinities are ignored, and an empty sample gives an empty histogram
+func AutoHistogram[R rules.Real](rule BinRule, xs ...R) Histogram {
+	fs := make([]float64, 0, len(xs))
+	for _, x := range xs {
+		if f := float64(x); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			fs = append(fs, f)
+		}
+	}
+	if len(fs) == 0 {
+		return Histogram{}
+	}
+	return NewHistogram(min(rule(fs), len(fs)), slices.Min(fs), slices.Max(fs), fs...)
+}
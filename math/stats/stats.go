@@ -0,0 +1,162 @@
+// Package stats offers descriptive statistics over slices of real numbers
+//
+// Summaries are computed in float64, whatever the input type, and are NaN
+// when they are undefined for the given data, eg the mean of no values or the
+// sample variance of one. Functions never reorder their arguments
+package stats
+
+import (
+	"math"
+	"slices"
+
+	"github.com/kendfss/rules"
+)
+
+// Mean returns the arithmetic mean
+func Mean[R rules.Real](xs ...R) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += float64(x)
+	}
+	return sum / float64(len(xs))
+}
+
+// sumSquares returns the sum of squared deviations from the mean
+func sumSquares[R rules.Real](xs []R) float64 {
+	mean, out := Mean(xs...), 0.0
+	for _, x := range xs {
+		d := float64(x) - mean
+		out += d * d
+	}
+	return out
+}
+
+// Variance returns the population variance
+func Variance[R rules.Real](xs ...R) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+	return sumSquares(xs) / float64(len(xs))
+}
+
+// SampleVariance returns the unbiased sample variance, with Bessel's correction
+func SampleVariance[R rules.Real](xs ...R) float64 {
+	if len(xs) < 2 {
+		return math.NaN()
+	}
+	return sumSquares(xs) / float64(len(xs)-1)
+}
+
+// StdDev returns the population standard deviation
+func StdDev[R rules.Real](xs ...R) float64 {
+	return math.Sqrt(Variance(xs...))
+}
+
+// SampleStdDev returns the sample standard deviation
+func SampleStdDev[R rules.Real](xs ...R) float64 {
+	return math.Sqrt(SampleVariance(xs...))
+}
+
+// Median returns the middle value, or the mean of the two middle values
+func Median[R rules.Real](xs ...R) float64 {
+	return Quantile(0.5, Linear, xs...)
+}
+
+// QuantileMethod chooses how Quantile picks a value that falls between two data points
+type QuantileMethod int
+
+const (
+	Linear   QuantileMethod = iota // interpolates between the two neighbours
+	Lower                          // takes the lower neighbour
+	Higher                         // takes the higher neighbour
+	Nearest                        // takes the nearest neighbour, or the even-indexed one on ties
+	Midpoint                       // takes the mean of the two neighbours
+)
+
+// Quantile returns the q-quantile, for q in [0, 1], using the given method
+// values are ranked from 0 to n-1 and the quantile sits at rank q*(n-1)
+func Quantile[R rules.Real](q float64, method QuantileMethod, xs ...R) float64 {
+	if len(xs) == 0 || !(q >= 0 && q <= 1) {
+		return math.NaN()
+	}
+	sorted := slices.Clone(xs)
+	slices.Sort(sorted)
+	rank := q * float64(len(sorted)-1)
+	lo, hi := int(math.Floor(rank)), int(math.Ceil(rank))
+	a, b := float64(sorted[lo]), float64(sorted[hi])
+	switch method {
+	case Lower:
+		return a
+	case Higher:
+		return b
+	case Nearest:
+		if frac := rank - float64(lo); frac < 0.5 || frac == 0.5 && lo%2 == 0 {
+			return a
+		}
+		return b
+	case Midpoint:
+		return (a + b) / 2
+	case Linear:
+		return a + (b-a)*(rank-float64(lo))
+	}
+	return math.NaN()
+}
+
+// Mode returns the most common values in ascending order
+func Mode[R rules.Real](xs ...R) []R {
+	counts := map[R]int{}
+	best := 0
+	for _, x := range xs {
+		counts[x]++
+		best = max(best, counts[x])
+	}
+	var out []R
+	for x, n := range counts {
+		if n == best {
+			out = append(out, x)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// codeviation returns the sum of the products of deviations from the means
+func codeviation[R rules.Real](xs, ys []R) float64 {
+	mx, my, out := Mean(xs...), Mean(ys...), 0.0
+	for i := range xs {
+		out += (float64(xs[i]) - mx) * (float64(ys[i]) - my)
+	}
+	return out
+}
+
+// Covariance returns the population covariance of two equally long samples
+func Covariance[R rules.Real](xs, ys []R) float64 {
+	if len(xs) == 0 || len(xs) != len(ys) {
+		return math.NaN()
+	}
+	return codeviation(xs, ys) / float64(len(xs))
+}
+
+// SampleCovariance returns the unbiased sample covariance of two equally long samples
+func SampleCovariance[R rules.Real](xs, ys []R) float64 {
+	if len(xs) < 2 || len(xs) != len(ys) {
+		return math.NaN()
+	}
+	return codeviation(xs, ys) / float64(len(xs)-1)
+}
+
+// Correlation returns Pearson's correlation coefficient of two equally long samples
+// it is NaN if either of them is constant
+func Correlation[R rules.Real](xs, ys []R) float64 {
+	if len(xs) == 0 || len(xs) != len(ys) {
+		return math.NaN()
+	}
+	den := math.Sqrt(sumSquares(xs) * sumSquares(ys))
+	if den == 0 {
+		return math.NaN()
+	}
+	return codeviation(xs, ys) / den
+}
@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	nTests = 10
+	nItems = 1000
+)
+
+func TestSummaries(t *testing.T) {
+	xs := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	assert.Equal(t, 5.0, Mean(xs...))
+	assert.Equal(t, 4.0, Variance(xs...))
+	assert.Equal(t, 2.0, StdDev(xs...))
+	assert.InDelta(t, 32.0/7, SampleVariance(xs...), 1e-12)
+	assert.InDelta(t, math.Sqrt(32.0/7), SampleStdDev(xs...), 1e-12)
+	assert.Equal(t, 4.5, Median(xs...))
+	assert.Equal(t, 2.0, Median(3, 1, 2))
+	assert.Equal(t, []int{4}, Mode(xs...))
+	assert.Equal(t, []float64{1, 2}, Mode(2.0, 1, 2, 1, 3))
+	assert.Equal(t, []int{2, 4, 4, 4, 5, 5, 7, 9}, xs, "input must not be reordered")
+
+	assert.True(t, math.IsNaN(Mean[int]()))
+	assert.True(t, math.IsNaN(Variance[float32]()))
+	assert.True(t, math.IsNaN(SampleVariance(1)))
+	assert.True(t, math.IsNaN(Median[int]()))
+	assert.Nil(t, Mode[int]())
+}
+
+func TestQuantile(t *testing.T) {
+	xs := []float64{4, 1, 3, 2} // rank of q=0.4 is 1.2, between 2 and 3
+	for method, want := range map[QuantileMethod]float64{
+		Linear:   2.2,
+		Lower:    2,
+		Higher:   3,
+		Nearest:  2,
+		Midpoint: 2.5,
+	} {
+		assert.InDelta(t, want, Quantile(0.4, method, xs...), 1e-12, "method %d", method)
+	}
+	assert.Equal(t, 1.0, Quantile(0, Linear, xs...))
+	assert.Equal(t, 4.0, Quantile(1, Higher, xs...))
+	// ties go to the even rank
+	assert.Equal(t, 3.0, Quantile(2.5/6, Nearest, 1, 2, 3, 4, 5, 6, 7))
+	assert.Equal(t, 3.0, Quantile(1.5/6, Nearest, 1, 2, 3, 4, 5, 6, 7))
+	assert.True(t, math.IsNaN(Quantile(1.5, Linear, xs...)))
+	assert.True(t, math.IsNaN(Quantile(math.NaN(), Linear, xs...)))
+	assert.True(t, math.IsNaN(Quantile(0.5, QuantileMethod(-1), xs...)))
+}
+
+func TestCorrelation(t *testing.T) {
+	xs, ys := []float64{1, 2, 3, 4}, []float64{2, 4, 6, 8}
+	assert.Equal(t, 2.5, Covariance(xs, ys))
+	assert.InDelta(t, 10.0/3, SampleCovariance(xs, ys), 1e-12)
+	assert.InDelta(t, 1.0, Correlation(xs, ys), 1e-12)
+	assert.InDelta(t, -1.0, Correlation(xs, []float64{4, 3, 2, 1}), 1e-12)
+	assert.True(t, math.IsNaN(Correlation(xs, []float64{1, 1, 1, 1})))
+	assert.True(t, math.IsNaN(Covariance(xs, ys[1:])))
+	assert.True(t, math.IsNaN(SampleCovariance(xs[:1], ys[:1])))
+}
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram(4, 0, 4, 0, 0.5, 1, 2.9, 3, 4, 5, -1, math.NaN())
+	assert.Equal(t, []float64{0, 1, 2, 3, 4}, h.Edges)
+	assert.Equal(t, []int{2, 1, 1, 2}, h.Counts)
+	assert.Equal(t, 6, h.Total())
+
+	h = NewHistogram(3, 1, 1, 1, 1)
+	assert.Equal(t, []int{0, 0, 2}, h.Counts)
+
+	xs := make([]float64, nItems)
+	for i := range xs {
+		xs[i] = rand.NormFloat64()
+	}
+	for _, rule := range []BinRule{Sturges, Scott, FreedmanDiaconis} {
+		h := AutoHistogram(rule, xs...)
+		assert.Equal(t, nItems, h.Total())
+		assert.Equal(t, len(h.Counts)+1, len(h.Edges))
+		assert.Greater(t, len(h.Counts), 5)
+	}
+	assert.Equal(t, 11, Sturges(xs))
+	assert.Equal(t, []int{3}, AutoHistogram(Scott, 7, 7, 7).Counts)
+	assert.Equal(t, Histogram{}, AutoHistogram[int](Sturges))
+
+	// an outlier must not demand a bin for every IQR-sized step up to it
+	outlier := []float64{0, 1, 2, 3, 4, 5, 6, 7, 1e13}
+	for _, rule := range []BinRule{Scott, FreedmanDiaconis} {
+		h := AutoHistogram(rule, outlier...)
+		assert.LessOrEqual(t, len(h.Counts), len(outlier))
+		assert.Equal(t, len(outlier), h.Total())
+	}
+	h = AutoHistogram(Sturges, 1, 2, math.Inf(1), math.Inf(-1), math.NaN())
+	assert.Equal(t, 2, h.Total())
+	assert.Equal(t, []float64{1, 2}, []float64{h.Edges[0], h.Edges[len(h.Edges)-1]})
+	h = NewHistogram(2, 0, math.Inf(1), 1, math.Inf(1))
+	assert.Equal(t, []int{0, 2}, h.Counts)
+}
+
+func TestAccumulator(t *testing.T) {
+	var acc Accumulator[float64]
+	assert.True(t, math.IsNaN(acc.Mean()))
+	assert.True(t, math.IsNaN(acc.SampleVariance()))
+
+	xs := make([]float64, nItems)
+	for i := range xs {
+		xs[i] = 1e9 + rand.Float64() // a large offset defeats the naive sum of squares
+	}
+	acc.Add(xs...)
+	assert.Equal(t, nItems, acc.Count())
+	assert.InEpsilon(t, Mean(xs...), acc.Mean(), 1e-12)
+	assert.InDelta(t, Variance(xs...), acc.Variance(), 1e-6)
+	assert.InDelta(t, SampleStdDev(xs...), acc.SampleStdDev(), 1e-6)
+
+	ints := []int{3, -1, 4, 1, -5, 9, 2, 6}
+	var whole Accumulator[int]
+	whole.Add(ints...)
+	assert.Equal(t, -5, whole.Min())
+	assert.Equal(t, 9, whole.Max())
+	assert.InDelta(t, StdDev(ints...), whole.StdDev(), 1e-12)
+
+	parts := make([]Accumulator[float64], nTests)
+	var wg sync.WaitGroup
+	for i := range parts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := i; j < nItems; j += nTests {
+				parts[i].Add(xs[j])
+			}
+		}()
+	}
+	wg.Wait()
+	var merged Accumulator[float64]
+	for _, p := range parts {
+		merged.Merge(p)
+	}
+	assert.Equal(t, acc.Count(), merged.Count())
+	assert.InEpsilon(t, acc.Mean(), merged.Mean(), 1e-12)
+	assert.InDelta(t, acc.Variance(), merged.Variance(), 1e-6)
+	assert.Equal(t, acc.Min(), merged.Min())
+	assert.Equal(t, acc.Max(), merged.Max())
+}
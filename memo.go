@@ -0,0 +1,219 @@
+package oprs
+
+import (
+	"container/list"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Clock tells the time, so that time-dependent tools can be tested without sleeping
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock backed by package time
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// MemoPanicError carries a value recovered from a panic in a memoized function,
+// along with the stack it was raised on
+type MemoPanicError struct {
+	Key   any    // key whose call panicked
+	Value any    // value passed to panic
+	Stack []byte // stack trace of the panicking goroutine
+}
+
+func (e *MemoPanicError) Error() string {
+	return fmt.Sprintf("oprs.memo: call for %v panicked: %v", e.Key, e.Value)
+}
+
+// CachePolicy bounds the cache of a Memo
+// The zero value is unbounded
+type CachePolicy struct {
+	Capacity int           // maximum number of entries, least recently used go first. unbounded if <= 0
+	TTL      time.Duration // maximum age of an entry. unlimited if <= 0
+	Clock    Clock         // used to age entries. defaults to SystemClock
+}
+
+// Unbounded returns a policy that never evicts
+func Unbounded() CachePolicy {
+	return CachePolicy{}
+}
+
+// LRU returns a policy that keeps, at most, the given number of most recently used entries
+func LRU(capacity int) CachePolicy {
+	return CachePolicy{Capacity: capacity}
+}
+
+// TTL returns a policy that expires entries once they reach the given age
+func TTL(ttl time.Duration) CachePolicy {
+	return CachePolicy{TTL: ttl}
+}
+
+// MemoStats counts the outcomes of calls to a Memo
+type MemoStats struct {
+	Hits      uint64 // calls answered from the cache
+	Misses    uint64 // calls that invoked the wrapped function
+	Shared    uint64 // calls that waited on a concurrent miss for the same key
+	Evictions uint64 // entries dropped by the policy
+}
+
+// Memo caches the results of a function
+// It is safe for concurrent use, and concurrent misses for the same key
+// share a single call to the wrapped function
+type Memo[K comparable, V any] struct {
+	f      func(K) (V, error)
+	policy CachePolicy
+
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	order   *list.List // most recently used at the front
+	calls   map[K]*memoCall[V]
+	stats   MemoStats
+}
+
+type memoEntry[K comparable, V any] struct {
+	key K
+	val V
+	at  time.Time
+}
+
+type memoCall[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// Memoize wraps a function in a cache governed by the given policy
+func Memoize[K comparable, V any](f func(K) V, policy CachePolicy) *Memo[K, V] {
+	return MemoizeErr(func(k K) (V, error) { return f(k), nil }, policy)
+}
+
+// MemoizeErr wraps a fallible function in a cache governed by the given policy
+// Errors are returned to every caller sharing the call, but are not cached
+func MemoizeErr[K comparable, V any](f func(K) (V, error), policy CachePolicy) *Memo[K, V] {
+	if policy.Clock == nil {
+		policy.Clock = SystemClock
+	}
+	return &Memo[K, V]{
+		f:       f,
+		policy:  policy,
+		entries: map[K]*list.Element{},
+		order:   list.New(),
+		calls:   map[K]*memoCall[V]{},
+	}
+}
+
+// Get returns the cached value for a key, calling the wrapped function on a miss
+// A panic in the wrapped function is re-raised as a *MemoPanicError carrying the original
+// stack, and concurrent callers waiting on the same key get it as their error
+func (m *Memo[K, V]) Get(key K) (V, error) {
+	m.mu.Lock()
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoEntry[K, V])
+		if !m.expired(entry) {
+			m.order.MoveToFront(elem)
+			m.stats.Hits++
+			m.mu.Unlock()
+			return entry.val, nil
+		}
+		m.remove(elem)
+	}
+	if call, ok := m.calls[key]; ok {
+		m.stats.Shared++
+		m.mu.Unlock()
+		<-call.done
+		return call.val, call.err
+	}
+	call := &memoCall[V]{done: make(chan struct{})}
+	m.calls[key] = call
+	m.stats.Misses++
+	m.mu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err := &MemoPanicError{Key: key, Value: r, Stack: debug.Stack()}
+			call.err = err
+			m.finish(key, call)
+			panic(err)
+		}
+	}()
+	call.val, call.err = m.f(key)
+	m.finish(key, call)
+	return call.val, call.err
+}
+
+// Call returns the cached value for a key, calling the wrapped function on a miss
+// It panics if the wrapped function returns an error. See Must
+func (m *Memo[K, V]) Call(key K) V {
+	val, err := m.Get(key)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Forget drops the cached value for a key, if any
+func (m *Memo[K, V]) Forget(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.entries[key]; ok {
+		m.order.Remove(elem)
+		delete(m.entries, key)
+	}
+}
+
+// Purge drops every cached value
+func (m *Memo[K, V]) Purge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = map[K]*list.Element{}
+	m.order.Init()
+}
+
+// Len returns the number of cached values, including any that have expired but not yet been evicted
+func (m *Memo[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// Stats returns a snapshot of the memo's hit/miss statistics
+func (m *Memo[K, V]) Stats() MemoStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
+
+// finish publishes the result of a call, caching it if it succeeded
+func (m *Memo[K, V]) finish(key K, call *memoCall[V]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.calls, key)
+	close(call.done)
+	if call.err != nil {
+		return
+	}
+	entry := &memoEntry[K, V]{key: key, val: call.val, at: m.policy.Clock.Now()}
+	m.entries[key] = m.order.PushFront(entry)
+	for m.policy.Capacity > 0 && m.order.Len() > m.policy.Capacity {
+		m.remove(m.order.Back())
+	}
+}
+
+// remove evicts an entry, the caller must hold the lock
+func (m *Memo[K, V]) remove(elem *list.Element) {
+	m.order.Remove(elem)
+	delete(m.entries, elem.Value.(*memoEntry[K, V]).key)
+	m.stats.Evictions++
+}
+
+// expired reports whether an entry has outlived the policy's TTL, the caller must hold the lock
+func (m *Memo[K, V]) expired(entry *memoEntry[K, V]) bool {
+	return m.policy.TTL > 0 && m.policy.Clock.Now().Sub(entry.at) >= m.policy.TTL
+}
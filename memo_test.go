@@ -0,0 +1,93 @@
+package oprs
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	square := Memoize(func(i int) int { calls++; return i * i }, LRU(2))
+	assert.Equal(t, 4, square.Call(2))
+	assert.Equal(t, 4, square.Call(2))
+	assert.Equal(t, 9, square.Call(3))
+	assert.Equal(t, 16, square.Call(4)) // evicts 2
+	assert.Equal(t, 4, square.Call(2))
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, MemoStats{Hits: 1, Misses: 4, Evictions: 2}, square.Stats())
+	assert.Equal(t, 2, square.Len())
+}
+
+func TestMemoizeTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	calls := 0
+	memo := Memoize(func(i int) int { calls++; return i }, CachePolicy{TTL: time.Minute, Clock: clock})
+	memo.Call(1)
+	clock.now = clock.now.Add(59 * time.Second)
+	memo.Call(1)
+	assert.Equal(t, 1, calls)
+	clock.now = clock.now.Add(time.Second)
+	memo.Call(1)
+	assert.Equal(t, 2, calls)
+}
+
+func TestMemoizeErr(t *testing.T) {
+	calls := 0
+	memo := MemoizeErr(func(i int) (int, error) {
+		calls++
+		return 0, errors.New("nope")
+	}, Unbounded())
+	_, err := memo.Get(1)
+	assert.EqualError(t, err, "nope")
+	assert.Panics(t, func() { memo.Call(1) })
+	assert.Equal(t, 2, calls, "errors should not be cached")
+}
+
+func TestMemoizePanic(t *testing.T) {
+	memo := Memoize(func(i int) int { panic("boom") }, Unbounded())
+	defer func() {
+		perr, ok := recover().(*MemoPanicError)
+		if assert.True(t, ok) {
+			assert.Equal(t, 7, perr.Key)
+			assert.EqualError(t, perr, "oprs.memo: call for 7 panicked: boom")
+			assert.Equal(t, "boom", perr.Value)
+			assert.Contains(t, string(perr.Stack), "TestMemoizePanic", "the stack should reach the panicking call")
+		}
+	}()
+	memo.Call(7)
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	memo := Memoize(func(i int) int {
+		calls.Add(1)
+		<-release
+		return i
+	}, Unbounded())
+
+	const n = 10
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, 7, memo.Call(7))
+		}()
+	}
+	assert.Eventually(t, func() bool { return memo.Stats().Shared == n-1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 7, memo.Call(7))
+	assert.Equal(t, MemoStats{Hits: 1, Misses: 1, Shared: n - 1}, memo.Stats())
+}
@@ -23,10 +23,9 @@ func DenullLener[Seq rules.Lener[K, V], K comparable, V any](ptr *Seq, val Seq)
 }
 
 // Clone duplicates an object in memory
+// It panics if the object cannot be copied, see DeepClone
 func Clone[T any](arg *T) *T {
-	out := new(T)
-	*out = *(*T)(unsafe.Pointer(&arg))
-	return out
+	return Must(DeepClone[*T])(arg)
 }
 
 // Value returns the value to the given pointer
@@ -61,7 +60,7 @@ func NotNil[T Niler[*any, any]](arg T) bool {
 
 // Msb checks if Most Significant Bit equals 1
 func Msb[T rules.Int](arg T) bool {
-	return (1 << Sizeof[T]() & arg) == 1
+	return arg>>(Sizeof[T]()-1)&1 == 1
 }
 
 // Lsb checks if Least Significant Bit equals 1
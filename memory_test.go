@@ -11,8 +11,11 @@ import (
 func TestClone(t *testing.T) {
 	x := big.NewInt(100)
 	y := Clone(x)
-	assert.NotEqual(t, x, y, "rereferencing does not work for big.Int %#v", x == y)
-	assert.NotEqual(t, x.Cmp(y), 0, "rereferencing does not work for big.Int")
+	assert.True(t, x != y, "the clone should not share the original's address")
+	assert.Equal(t, 0, x.Cmp(y), "the clone should equal the original")
+	y.Add(y, y)
+	assert.Equal(t, int64(100), x.Int64(), "modifying the clone should not modify the original")
+	assert.Panics(t, func() { Clone(&struct{ ch chan int }{make(chan int)}) })
 }
 
 func TestByteSliceOperators(t *testing.T) {
@@ -76,3 +79,12 @@ func TestByteSliceOperators(t *testing.T) {
 		run(tests, "Move")
 	})
 }
+
+func TestMsbLsb(t *testing.T) {
+	assert.True(t, Msb(int8(-1)))
+	assert.True(t, Msb(uint16(1<<15)))
+	assert.False(t, Msb(uint16(1<<14)))
+	assert.False(t, Msb(1))
+	assert.True(t, Lsb(int32(-1)))
+	assert.False(t, Lsb(uint(2)))
+}
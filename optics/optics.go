@@ -0,0 +1,202 @@
+// Package optics offers composable, immutable accessors for nested data
+//
+// A Lens focuses on a part that is always present, a Prism on a part that may be
+// absent (a nil pointer, or one implementation of an interface), and a Traversal
+// on any number of parts (the elements of a slice). Setters never mutate their
+// input, they return an updated copy along the focused path
+package optics
+
+import "github.com/kendfss/oprs"
+
+// Lens focuses on a part A of a whole S
+type Lens[S, A any] struct {
+	Get func(S) A
+	Set func(S, A) S
+}
+
+// NewLens builds a lens from a getter and a copy-on-write setter
+func NewLens[S, A any](get func(S) A, set func(S, A) S) Lens[S, A] {
+	return Lens[S, A]{Get: get, Set: set}
+}
+
+// Modify lifts an operator on the part into one on the whole
+func (l Lens[S, A]) Modify(f oprs.Op[A]) oprs.Op[S] {
+	return func(s S) S {
+		return l.Set(s, f(l.Get(s)))
+	}
+}
+
+// Traversal views the lens as a traversal with exactly one target
+func (l Lens[S, A]) Traversal() Traversal[S, A] {
+	return Traversal[S, A]{
+		GetAll: func(s S) []A { return []A{l.Get(s)} },
+		Over:   func(s S, f func(A) A) S { return l.Modify(f)(s) },
+	}
+}
+
+// Compose focuses the inner lens through the outer one
+func Compose[S, A, B any](outer Lens[S, A], inner Lens[A, B]) Lens[S, B] {
+	return Lens[S, B]{
+		Get: oprs.Pipe(outer.Get, inner.Get),
+		Set: func(s S, b B) S {
+			return outer.Set(s, inner.Set(outer.Get(s), b))
+		},
+	}
+}
+
+// Index focuses on the ith element of a slice
+// Get panics, like an index expression, if i is out of range
+func Index[A any](i int) Lens[[]A, A] {
+	return Lens[[]A, A]{
+		Get: func(s []A) A { return s[i] },
+		Set: func(s []A, a A) []A {
+			out := append([]A(nil), s...)
+			out[i] = a
+			return out
+		},
+	}
+}
+
+// Key focuses on the value stored under a map key
+// Get returns the zero value if the key is absent, Set adds it
+func Key[K comparable, V any](k K) Lens[map[K]V, V] {
+	return Lens[map[K]V, V]{
+		Get: func(m map[K]V) V { return m[k] },
+		Set: func(m map[K]V, v V) map[K]V {
+			out := make(map[K]V, len(m)+1)
+			for key, val := range m {
+				out[key] = val
+			}
+			out[k] = v
+			return out
+		},
+	}
+}
+
+// Prism focuses on a part A of a whole S that may be absent
+type Prism[S, A any] struct {
+	Preview func(S) (A, bool) // extracts the part, if present
+	Review  func(A) S         // builds a whole from the part
+}
+
+// NewPrism builds a prism from a partial getter and a constructor
+func NewPrism[S, A any](preview func(S) (A, bool), review func(A) S) Prism[S, A] {
+	return Prism[S, A]{Preview: preview, Review: review}
+}
+
+// Modify lifts an operator on the part into one on the whole
+// wholes without the part are returned unchanged
+func (p Prism[S, A]) Modify(f oprs.Op[A]) oprs.Op[S] {
+	return func(s S) S {
+		if a, ok := p.Preview(s); ok {
+			return p.Review(f(a))
+		}
+		return s
+	}
+}
+
+// Set replaces the part, if present
+func (p Prism[S, A]) Set(s S, a A) S {
+	return p.Modify(func(A) A { return a })(s)
+}
+
+// Traversal views the prism as a traversal with, at most, one target
+func (p Prism[S, A]) Traversal() Traversal[S, A] {
+	return Traversal[S, A]{
+		GetAll: func(s S) []A {
+			if a, ok := p.Preview(s); ok {
+				return []A{a}
+			}
+			return nil
+		},
+		Over: func(s S, f func(A) A) S { return p.Modify(f)(s) },
+	}
+}
+
+// ComposePrism focuses the inner prism through the outer one
+func ComposePrism[S, A, B any](outer Prism[S, A], inner Prism[A, B]) Prism[S, B] {
+	return Prism[S, B]{
+		Preview: func(s S) (B, bool) {
+			if a, ok := outer.Preview(s); ok {
+				return inner.Preview(a)
+			}
+			return *new(B), false
+		},
+		Review: oprs.Pipe(inner.Review, outer.Review),
+	}
+}
+
+// As focuses on the values of an interface type S whose dynamic type is A
+func As[S, A any]() Prism[S, A] {
+	return Prism[S, A]{
+		Preview: func(s S) (A, bool) {
+			a, ok := any(s).(A)
+			return a, ok
+		},
+		Review: func(a A) S { return any(a).(S) },
+	}
+}
+
+// Deref focuses on the value behind a non-nil pointer
+// Setting through it allocates a new pointer instead of writing through the old one
+func Deref[A any]() Prism[*A, A] {
+	return Prism[*A, A]{
+		Preview: func(p *A) (A, bool) {
+			if p == nil {
+				return *new(A), false
+			}
+			return *p, true
+		},
+		Review: oprs.Pointer[A],
+	}
+}
+
+// Traversal focuses on any number of parts A of a whole S
+type Traversal[S, A any] struct {
+	GetAll func(S) []A          // extracts every part
+	Over   func(S, func(A) A) S // applies a function to every part
+}
+
+// Modify lifts an operator on the parts into one on the whole
+func (t Traversal[S, A]) Modify(f oprs.Op[A]) oprs.Op[S] {
+	return func(s S) S {
+		return t.Over(s, f)
+	}
+}
+
+// Set replaces every part
+func (t Traversal[S, A]) Set(s S, a A) S {
+	return t.Over(s, func(A) A { return a })
+}
+
+// ComposeTraversal focuses the inner traversal through the outer one
+func ComposeTraversal[S, A, B any](outer Traversal[S, A], inner Traversal[A, B]) Traversal[S, B] {
+	return Traversal[S, B]{
+		GetAll: func(s S) (out []B) {
+			for _, a := range outer.GetAll(s) {
+				out = append(out, inner.GetAll(a)...)
+			}
+			return out
+		},
+		Over: func(s S, f func(B) B) S {
+			return outer.Over(s, func(a A) A { return inner.Over(a, f) })
+		},
+	}
+}
+
+// Each focuses on every element of a slice
+func Each[A any]() Traversal[[]A, A] {
+	return Traversal[[]A, A]{
+		GetAll: func(s []A) []A { return append([]A(nil), s...) },
+		Over: func(s []A, f func(A) A) []A {
+			if s == nil {
+				return nil
+			}
+			out := make([]A, len(s))
+			for i, a := range s {
+				out[i] = f(a)
+			}
+			return out
+		},
+	}
+}
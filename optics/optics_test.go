@@ -0,0 +1,85 @@
+package optics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kendfss/oprs"
+)
+
+type (
+	street  struct{ Name string }
+	address struct{ Street street }
+	person  struct {
+		Address *address
+		Pets    []pet
+	}
+	pet  interface{ sound() string }
+	dog  struct{ Age int }
+	fish struct{}
+)
+
+func (dog) sound() string  { return "woof" }
+func (fish) sound() string { return "" }
+
+var (
+	personAddress = NewLens(
+		func(p person) *address { return p.Address },
+		func(p person, a *address) person { p.Address = a; return p },
+	)
+	addressStreet = NewLens(
+		func(a address) street { return a.Street },
+		func(a address, s street) address { a.Street = s; return a },
+	)
+	streetName = NewLens(
+		func(s street) string { return s.Name },
+		func(s street, n string) street { s.Name = n; return s },
+	)
+	personPets = NewLens(
+		func(p person) []pet { return p.Pets },
+		func(p person, ps []pet) person { p.Pets = ps; return p },
+	)
+	dogAge = NewLens(
+		func(d dog) int { return d.Age },
+		func(d dog, age int) dog { d.Age = age; return d },
+	)
+)
+
+func TestLens(t *testing.T) {
+	orig := person{Address: &address{street{"elm"}}}
+	name := ComposeTraversal(
+		personAddress.Traversal(),
+		ComposeTraversal(Deref[address]().Traversal(), Compose(addressStreet, streetName).Traversal()),
+	)
+	upper := name.Modify(func(s string) string { return s + " st" })(orig)
+
+	assert.Equal(t, "elm st", upper.Address.Street.Name)
+	assert.Equal(t, "elm", orig.Address.Street.Name, "the original should not be mutated")
+	assert.Equal(t, []string{"elm"}, name.GetAll(orig))
+	assert.Equal(t, person{}, name.Set(person{}, "oak"), "nil pointers should be skipped")
+
+	assert.Equal(t, []int{1, 9}, Index[int](1).Set([]int{1, 2}, 9))
+	m := map[string]int{"a": 1}
+	assert.Equal(t, map[string]int{"a": 2}, Key[string, int]("a").Modify(oprs.Bind(oprs.Add[int], 1))(m))
+	assert.Equal(t, 1, m["a"])
+}
+
+func TestTraversal(t *testing.T) {
+	orig := person{Pets: []pet{dog{1}, fish{}, dog{3}}}
+	ages := ComposeTraversal(
+		personPets.Traversal(),
+		ComposeTraversal(Each[pet](), ComposePrism(As[pet, dog](), NewPrism(
+			func(d dog) (int, bool) { return d.Age, d.Age > 1 },
+			func(age int) dog { return dog{age} },
+		)).Traversal()),
+	)
+	older := ages.Modify(oprs.Bind(oprs.Mul[int], 10))(orig)
+	assert.Equal(t, []pet{dog{1}, fish{}, dog{30}}, older.Pets)
+	assert.Equal(t, []pet{dog{1}, fish{}, dog{3}}, orig.Pets)
+	assert.Equal(t, []int{30}, ages.GetAll(older))
+
+	all := ComposeTraversal(Each[pet](), As[pet, dog]().Traversal())
+	assert.Equal(t, []pet{dog{0}, fish{}, dog{0}}, all.Set(orig.Pets, dog{}))
+	assert.Equal(t, dog{5}, dogAge.Modify(oprs.Bind(oprs.Add[int], 4))(dog{1}))
+}
@@ -0,0 +1,123 @@
+package oprs
+
+import "errors"
+
+// ErrNone is the error carried by an empty Option
+var ErrNone = errors.New("oprs.option: none")
+
+// Some wraps a value in an Option that always succeeds
+func Some[T any](val T) Option[T] {
+	return func() (T, error) {
+		return val, nil
+	}
+}
+
+// None returns an empty Option whose error is ErrNone
+func None[T any]() Option[T] {
+	return func() (T, error) {
+		return *new(T), ErrNone
+	}
+}
+
+// FromErr wraps the results of a fallible call in an Option
+// eg: FromErr(strconv.Atoi(s))
+func FromErr[T any](val T, err error) Option[T] {
+	if err != nil {
+		return func() (T, error) {
+			return *new(T), err
+		}
+	}
+	return Some(val)
+}
+
+// Get unpacks the option into its value and error
+func (o Option[T]) Get() (T, error) {
+	return o()
+}
+
+// Err returns the error carried by the option, if any
+func (o Option[T]) Err() error {
+	_, err := o()
+	return err
+}
+
+// IsSome returns true iff the option holds a value
+func (o Option[T]) IsSome() bool {
+	return o.Err() == nil
+}
+
+// IsNone returns true iff the option holds an error
+func (o Option[T]) IsNone() bool {
+	return o.Err() != nil
+}
+
+// Unwrap returns the option's value and panics if it holds an error
+// See Must
+func (o Option[T]) Unwrap() T {
+	val, err := o()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// UnwrapOr returns the option's value, or the given fallback if it holds an error
+func (o Option[T]) UnwrapOr(val T) T {
+	if out, err := o(); err == nil {
+		return out
+	}
+	return val
+}
+
+// OrElse returns the option if it holds a value, otherwise the alternative
+func (o Option[T]) OrElse(alt Option[T]) Option[T] {
+	return func() (T, error) {
+		if val, err := o(); err == nil {
+			return val, nil
+		}
+		return alt()
+	}
+}
+
+// Map transforms a function between two types into one between options of their instances
+// errors are passed through without calling the function
+func Map[I, O any](f func(I) O) func(Option[I]) Option[O] {
+	return func(arg Option[I]) Option[O] {
+		return func() (O, error) {
+			val, err := arg()
+			if err != nil {
+				return *new(O), err
+			}
+			return f(val), nil
+		}
+	}
+}
+
+// FlatMap transforms an option-returning function into one between options
+// errors are passed through without calling the function
+func FlatMap[I, O any](f func(I) Option[O]) func(Option[I]) Option[O] {
+	return func(arg Option[I]) Option[O] {
+		return func() (O, error) {
+			val, err := arg()
+			if err != nil {
+				return *new(O), err
+			}
+			return f(val)()
+		}
+	}
+}
+
+// Lift turns a fallible function into one that returns an Option
+// so that it can be used in Pipe chains
+func Lift[I, O any](f func(I) (O, error)) func(I) Option[O] {
+	return func(arg I) Option[O] {
+		return FromErr(f(arg))
+	}
+}
+
+// Unlift turns an Option-returning function back into a fallible one
+func Unlift[I, O any](f func(I) Option[O]) func(I) (O, error) {
+	return func(arg I) (O, error) {
+		return f(arg)()
+	}
+}
@@ -0,0 +1,34 @@
+package oprs
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOption(t *testing.T) {
+	atoi := Lift(strconv.Atoi)
+	double := Map(func(i int) int { return i * 2 })
+
+	assert.Equal(t, 84, Pipe(atoi, double)("42").Unwrap())
+	assert.True(t, Pipe(atoi, double)("x").IsNone())
+	assert.Equal(t, -1, Pipe(atoi, double)("x").UnwrapOr(-1))
+	assert.Equal(t, 7, None[int]().OrElse(Some(7)).Unwrap())
+	assert.ErrorIs(t, None[int]().Err(), ErrNone)
+	assert.Panics(t, func() { None[int]().Unwrap() })
+
+	half := FlatMap(func(i int) Option[int] {
+		if IsOdd(i) {
+			return FromErr(0, errors.New("odd"))
+		}
+		return Some(i / 2)
+	})
+	assert.Equal(t, 3, half(Some(6)).Unwrap())
+	assert.EqualError(t, half(Some(5)).Err(), "odd")
+
+	val, err := Unlift(atoi)("12")
+	assert.NoError(t, err)
+	assert.Equal(t, 12, val)
+}
@@ -0,0 +1,99 @@
+package oprs
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// PanicError carries a value recovered from a panic while processing an element
+type PanicError struct {
+	Index int    // position of the element being processed
+	Value any    // value passed to panic
+	Stack []byte // stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("oprs.parallel: element %d panicked: %v", e.Index, e.Value)
+}
+
+// ParallelIntegrate is like Integrate, but spreads the calls over a bounded number of goroutines
+// The output keeps the order of the input. If workers is non-positive, GOMAXPROCS is used
+// A panic in f is re-raised on the calling goroutine as a *PanicError
+func ParallelIntegrate[I, O any](f func(I) O, workers int) func([]I) []O {
+	g := func(arg I) (O, error) {
+		return f(arg), nil
+	}
+	return func(args []I) []O {
+		out, err := ParallelIntegrateErr(g, workers)(context.Background(), args)
+		if err != nil {
+			panic(err)
+		}
+		return out
+	}
+}
+
+// ParallelIntegrateErr is like ParallelIntegrate, for fallible functions
+// The first error, or panic, cancels the elements that have not yet started and is returned
+// alongside a nil slice. Panics are recovered into *PanicError values
+func ParallelIntegrateErr[I, O any](f func(I) (O, error), workers int) func(context.Context, []I) ([]O, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return func(ctx context.Context, args []I) ([]O, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			out   = make([]O, len(args))
+			next  atomic.Int64
+			once  sync.Once
+			first error
+			wg    sync.WaitGroup
+		)
+		fail := func(err error) {
+			once.Do(func() {
+				first = err
+				cancel()
+			})
+		}
+		call := func(i int) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = &PanicError{Index: i, Value: r, Stack: debug.Stack()}
+				}
+			}()
+			out[i], err = f(args[i])
+			return err
+		}
+
+		for w := 0; w < min(workers, len(args)); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for ctx.Err() == nil {
+					i := int(next.Add(1) - 1)
+					if i >= len(args) {
+						return
+					}
+					if err := call(i); err != nil {
+						fail(err)
+					}
+				}
+				fail(ctx.Err())
+			}()
+		}
+		wg.Wait()
+
+		if first == nil {
+			first = ctx.Err()
+		}
+		if first != nil {
+			return nil, first
+		}
+		return out, nil
+	}
+}
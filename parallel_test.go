@@ -0,0 +1,70 @@
+package oprs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelIntegrate(t *testing.T) {
+	var running, peak atomic.Int32
+	square := func(i int) int {
+		n := running.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return i * i
+	}
+	args := make([]int, 100)
+	for i := range args {
+		args[i] = i
+	}
+	assert.Equal(t, Integrate(square)(args), ParallelIntegrate(square, 4)(args))
+	assert.LessOrEqual(t, peak.Load(), int32(4))
+	assert.Empty(t, ParallelIntegrate(square, 0)(nil))
+
+	assert.PanicsWithError(t, "oprs.parallel: element 3 panicked: boom", func() {
+		ParallelIntegrate(func(i int) int {
+			if i == 3 {
+				panic("boom")
+			}
+			return i
+		}, 2)(args[:5])
+	})
+}
+
+func TestParallelIntegrateErr(t *testing.T) {
+	var calls atomic.Int32
+	fail := errors.New("fail")
+	fn := ParallelIntegrateErr(func(i int) (int, error) {
+		calls.Add(1)
+		if i == 10 {
+			return 0, fail
+		}
+		time.Sleep(time.Millisecond)
+		return i, nil
+	}, 2)
+	args := make([]int, 1000)
+	for i := range args {
+		args[i] = i
+	}
+	out, err := fn(context.Background(), args)
+	assert.ErrorIs(t, err, fail)
+	assert.Nil(t, out)
+	assert.Less(t, calls.Load(), int32(20))
+
+	_, err = ParallelIntegrateErr(func(i int) (int, error) { return 10 / i, nil }, 3)(context.Background(), []int{1, 0, 2})
+	var perr *PanicError
+	assert.ErrorAs(t, err, &perr)
+	assert.Equal(t, 1, perr.Index)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = fn(ctx, args)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -1,46 +1,264 @@
 package oprs
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
 	"strconv"
-	"unsafe"
+	"strings"
 
 	"github.com/kendfss/rules"
 )
 
+// TryParseIntn parses an integer-string of arbitrary base into the given type
+// it picks strconv.ParseInt or strconv.ParseUint from T's kind and reports
+// range errors against T's width
+// float and complex targets accept any integer that fits in 64 bits
+func TryParseIntn[T rules.Number](n int, s string) (T, error) {
+	k := kindOf[T]()
+	switch {
+	case k.float || k.complex:
+		i, err := strconv.ParseInt(s, n, 64)
+		if errors.Is(err, strconv.ErrRange) && s[0] != '-' {
+			u, err := strconv.ParseUint(s, n, 64)
+			return numberOf[T](float64(u)), err
+		}
+		return numberOf[T](float64(i)), err
+	case k.signed:
+		i, err := strconv.ParseInt(s, n, k.bits)
+		return numberOf[T](i), err
+	default:
+		u, err := strconv.ParseUint(s, n, k.bits)
+		return numberOf[T](u), err
+	}
+}
+
+// TryParseInt parses a base 10 integer-string into the given type
+func TryParseInt[T rules.Number](s string) (T, error) {
+	return TryParseIntn[T](10, s)
+}
+
+// TryParseBin parses a base 2 integer-string into the given type
+func TryParseBin[T rules.Number](s string) (T, error) {
+	return TryParseIntn[T](2, s)
+}
+
+// TryParseHex parses a base 16 integer-string into the given type
+func TryParseHex[T rules.Number](s string) (T, error) {
+	return TryParseIntn[T](16, s)
+}
+
+// TryParseFloat parses a decimal-string into the given type
+// it picks strconv.ParseFloat or strconv.ParseComplex from T's kind
+// integer targets accept exact integers and whole-valued decimals, such as "1e3", which
+// are parsed exactly, and report an ErrTruncated if the value has a fractional part,
+// or a strconv.ErrRange if it does not fit in T
+func TryParseFloat[T rules.Number](s string) (T, error) {
+	k := kindOf[T]()
+	switch {
+	case k.complex:
+		c, err := strconv.ParseComplex(s, k.bits*2)
+		return numberOf[T](c), err
+	case k.float:
+		f, err := strconv.ParseFloat(s, k.bits)
+		return numberOf[T](f), err
+	}
+	out, err := TryParseInt[T](s)
+	if !errors.Is(err, strconv.ErrSyntax) {
+		return out, err
+	}
+	// parse the decimal exactly, as float64 would round away digits beyond 2^53
+	d, err := TryParseDecimal(s)
+	if err != nil {
+		return 0, &strconv.NumError{Func: "TryParseFloat", Num: s, Err: err.(*strconv.NumError).Err}
+	}
+	if k.signed {
+		i, err := TryCastDecimal[int64](d)
+		if err != nil {
+			return 0, parseCastErr("TryParseFloat", s, err)
+		}
+		return TryParseInt[T](strconv.FormatInt(i, 10))
+	}
+	u, err := TryCastDecimal[uint64](d)
+	if err != nil {
+		return 0, parseCastErr("TryParseFloat", s, err)
+	}
+	return TryParseInt[T](strconv.FormatUint(u, 10))
+}
+
+// parseCastErr reports a failed cast of a parsed value in the terms of strconv:
+// a fraction stays an ErrTruncated, and anything else that does not fit is an ErrRange
+func parseCastErr(fn, s string, err error) error {
+	if !errors.Is(err, ErrTruncated) {
+		err = strconv.ErrRange
+	}
+	return &strconv.NumError{Func: fn, Num: s, Err: err}
+}
+
+// numberOf converts a parsed int64, uint64, float64 or complex128 into any numeric type
+// real values are given a zero imaginary part when T is complex
+func numberOf[T rules.Number](val any) T {
+	var out T
+	v, t := reflect.ValueOf(val), reflect.TypeOf(out)
+	if k := t.Kind(); (k == reflect.Complex64 || k == reflect.Complex128) && !v.CanComplex() {
+		v = reflect.ValueOf(complex(v.Convert(reflect.TypeOf(0.0)).Float(), 0))
+	}
+	reflect.ValueOf(&out).Elem().Set(v.Convert(t))
+	return out
+}
+
 // ParseIntn parses an integer-string of arbitrary base into the given type
-// under the hood, it's a panicky-wrapper on strconv.ParseInt
-func ParseIntn[T rules.Real](n int, s string) T {
-	i, err := strconv.ParseInt(s, n, int(unsafe.Sizeof(*new(T))*8))
+// under the hood, it's a panicky-wrapper on TryParseIntn
+func ParseIntn[T rules.Number](n int, s string) T {
+	out, err := TryParseIntn[T](n, s)
 	if err != nil {
 		panic(err)
 	}
-	return T(i)
+	return out
 }
 
 // ParseInt parses a base 10 integer-string into the given type
-// under the hood, it's a panicky-wrapper on strconv.ParseInt
-func ParseInt[T rules.Real](s string) T {
+// under the hood, it's a panicky-wrapper on TryParseIntn
+func ParseInt[T rules.Number](s string) T {
 	return ParseIntn[T](10, s)
 }
 
 // ParseBin parses a base 2 integer-string into the given type
-// under the hood, it's a panicky-wrapper on strconv.ParseInt
-func ParseBin[T rules.Real](s string) T {
+// under the hood, it's a panicky-wrapper on TryParseIntn
+func ParseBin[T rules.Number](s string) T {
 	return ParseIntn[T](2, s)
 }
 
 // ParseHex parses a base 16 integer-string into the given type
-// under the hood, it's a panicky-wrapper on strconv.ParseInt
-func ParseHex[T rules.Real](s string) T {
+// under the hood, it's a panicky-wrapper on TryParseIntn
+func ParseHex[T rules.Number](s string) T {
 	return ParseIntn[T](16, s)
 }
 
 // ParseFloat parses a decimal-string into the given type
-// under the hood, it's a panicky-wrapper on strconv.ParseInt
-func ParseFloat[T rules.Real](s string) T {
-	i, err := strconv.ParseFloat(s, int(unsafe.Sizeof(*new(T))*8))
+// integer targets truncate any fraction towards zero, so "2.5" becomes 2, but still panic on overflow
+// under the hood, it's a panicky-wrapper on TryParseFloat
+func ParseFloat[T rules.Number](s string) T {
+	out, err := TryParseFloat[T](s)
+	if errors.Is(err, ErrTruncated) {
+		out, err = TryParseFloat[T](ParseDecimal(s).Round(0, RoundTruncate).String())
+	}
 	if err != nil {
 		panic(err)
 	}
-	return T(i)
+	return out
+}
+
+// TryParseBigIntn parses an integer-string of arbitrary base into a *big.Int
+// a base of 0 infers it from the string's prefix, as in Go literals
+func TryParseBigIntn(n int, s string) (*big.Int, error) {
+	out, ok := new(big.Int).SetString(s, n)
+	if !ok {
+		return nil, &strconv.NumError{Func: "TryParseBigIntn", Num: s, Err: strconv.ErrSyntax}
+	}
+	return out, nil
+}
+
+// TryParseBigInt parses a base 10 integer-string into a *big.Int
+func TryParseBigInt(s string) (*big.Int, error) {
+	return TryParseBigIntn(10, s)
+}
+
+// TryParseBigRat parses a fraction, such as "-3/4", or a decimal-string, such as "1.25e-3", into an exact *big.Rat
+func TryParseBigRat(s string) (*big.Rat, error) {
+	out, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, &strconv.NumError{Func: "TryParseBigRat", Num: s, Err: strconv.ErrSyntax}
+	}
+	return out, nil
+}
+
+// TryParseBigFloat parses a decimal-string into a *big.Float with the given precision
+// a precision of 0 is taken to mean 64 bits
+func TryParseBigFloat(prec uint, s string) (*big.Float, error) {
+	out, _, err := big.ParseFloat(s, 10, Ternary(prec == 0, 64, prec), big.ToNearestEven)
+	if err != nil {
+		// like the rest of the family, report ErrSyntax or ErrRange, keeping big's reason in the message
+		// big has no sentinel for an exponent that overflows its int32, only this text
+		cause := strconv.ErrSyntax
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) && errors.Is(numErr.Err, strconv.ErrRange) || strings.Contains(err.Error(), "exponent overflow") {
+			cause = strconv.ErrRange
+		}
+		return nil, &strconv.NumError{Func: "TryParseBigFloat", Num: s, Err: fmt.Errorf("%w: %v", cause, err)}
+	}
+	return out, nil
+}
+
+// ParseBigIntn parses an integer-string of arbitrary base into a *big.Int
+// under the hood, it's a panicky-wrapper on TryParseBigIntn
+func ParseBigIntn(n int, s string) *big.Int {
+	out, err := TryParseBigIntn(n, s)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// ParseBigInt parses a base 10 integer-string into a *big.Int
+// under the hood, it's a panicky-wrapper on TryParseBigIntn
+func ParseBigInt(s string) *big.Int {
+	return ParseBigIntn(10, s)
+}
+
+// ParseBigRat parses a fraction or decimal-string into a *big.Rat
+// under the hood, it's a panicky-wrapper on TryParseBigRat
+func ParseBigRat(s string) *big.Rat {
+	return Must(TryParseBigRat)(s)
+}
+
+// ParseBigFloat parses a decimal-string into a *big.Float with the given precision
+// under the hood, it's a panicky-wrapper on TryParseBigFloat
+func ParseBigFloat(prec uint, s string) *big.Float {
+	out, err := TryParseBigFloat(prec, s)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// maxDecimalScale bounds the scale of parsed Decimals, to keep "1e999999999" from exhausting memory
+const maxDecimalScale = 1 << 16
+
+// TryParseDecimal parses a decimal-string, such as "-12.50" or "1.5e-3", exactly into a Decimal
+// its scale is the number of digits after the point, less the exponent, so "12.50" keeps its trailing zero
+func TryParseDecimal(s string) (Decimal, error) {
+	fail := func(err error) (Decimal, error) {
+		return Decimal{}, &strconv.NumError{Func: "TryParseDecimal", Num: s, Err: err}
+	}
+	mant, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return fail(err.(*strconv.NumError).Err)
+		}
+		mant, exp = s[:i], e
+	}
+	sign := ""
+	if mant != "" && (mant[0] == '+' || mant[0] == '-') {
+		sign, mant = mant[:1], mant[1:]
+	}
+	whole, frac, _ := strings.Cut(mant, ".")
+	digits := whole + frac
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return fail(strconv.ErrSyntax)
+	}
+	scale := int64(len(frac)) - exp
+	if scale < -maxDecimalScale || scale > maxDecimalScale {
+		return fail(strconv.ErrRange)
+	}
+	coef, _ := new(big.Int).SetString(sign+digits, 10)
+	return decimalOf(coef, int(scale)), nil
+}
+
+// ParseDecimal parses a decimal-string exactly into a Decimal
+// under the hood, it's a panicky-wrapper on TryParseDecimal
+func ParseDecimal(s string) Decimal {
+	return Must(TryParseDecimal)(s)
 }
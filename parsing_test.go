@@ -0,0 +1,130 @@
+package oprs
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryParse(t *testing.T) {
+	u, err := TryParseHex[uint64]("ffffffffffffffff")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1<<64-1), u)
+
+	_, err = TryParseInt[int8]("128")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	_, err = TryParseBin[uint8]("100000000")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	_, err = TryParseInt[uint]("-1")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	f, err := TryParseHex[float64]("ff")
+	assert.NoError(t, err)
+	assert.Equal(t, 255.0, f)
+
+	i, err := TryParseFloat[int16]("1e3")
+	assert.NoError(t, err)
+	assert.Equal(t, int16(1000), i)
+	_, err = TryParseFloat[int]("2.5")
+	assert.ErrorIs(t, err, ErrTruncated)
+	i64, err := TryParseFloat[int64]("9007199254740993.0")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), i64)
+	u64, err := TryParseFloat[uint64]("1.8446744073709551615e19")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1<<64-1), u64)
+	_, err = TryParseFloat[int64]("1e19")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	_, err = TryParseFloat[int8]("1.28e2")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	_, err = TryParseFloat[uint]("-1.0")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	_, err = TryParseFloat[int]("1.5.0")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	_, err = TryParseFloat[float32]("1e39")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+
+	c, err := TryParseFloat[complex64]("1+2i")
+	assert.NoError(t, err)
+	assert.Equal(t, complex64(1+2i), c)
+	c2, err := TryParseInt[complex128]("-7")
+	assert.NoError(t, err)
+	assert.Equal(t, complex128(-7), c2)
+
+	assert.Equal(t, uint64(1<<64-1), ParseHex[uint64]("ffffffffffffffff"))
+	assert.Panics(t, func() { ParseInt[int8]("300") })
+
+	// the panicky wrapper keeps its truncating behaviour for integer targets
+	assert.Equal(t, 2, ParseFloat[int]("2.5"))
+	assert.Equal(t, -2, ParseFloat[int]("-2.99"))
+	assert.Equal(t, int64(9007199254740993), ParseFloat[int64]("9007199254740993.9"))
+	assert.Equal(t, uint64(1<<64-1), ParseFloat[uint64]("18446744073709551615"))
+	assert.Equal(t, 2.5, ParseFloat[float64]("2.5"))
+	assert.Panics(t, func() { ParseFloat[int8]("300.5") })
+	assert.Panics(t, func() { ParseFloat[uint8]("-1.5") })
+}
+
+func TestTryParseBig(t *testing.T) {
+	i, err := TryParseBigIntn(16, "-ffffffffffffffffffff")
+	assert.NoError(t, err)
+	assert.Equal(t, "-1208925819614629174706175", i.String())
+	i, err = TryParseBigIntn(0, "0b101")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), i.Int64())
+	_, err = TryParseBigInt("12a")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	r, err := TryParseBigRat("-6/8")
+	assert.NoError(t, err)
+	assert.Equal(t, "-3/4", r.RatString())
+	assert.Equal(t, "1/800", ParseBigRat("1.25e-3").RatString())
+	_, err = TryParseBigRat("1/0")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	f, err := TryParseBigFloat(0, "0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(64), f.Prec())
+	assert.Equal(t, "0.1000000000000000000000000000000", ParseBigFloat(256, "0.1").Text('f', 31))
+	_, err = TryParseBigFloat(53, "one")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.ErrorContains(t, err, "number has no digits")
+	_, err = TryParseBigFloat(53, "1.5x")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	_, err = TryParseBigFloat(53, "1e99999999999999999999")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	_, err = TryParseBigFloat(53, "1e9999999999")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	assert.ErrorContains(t, err, "exponent overflow")
+
+	assert.Panics(t, func() { ParseBigInt("") })
+}
+
+func TestTryParseDecimal(t *testing.T) {
+	for in, want := range map[string]string{
+		"12.50":   "12.50",
+		"-0.05":   "-0.05",
+		"+7":      "7",
+		".5":      "0.5",
+		"5.":      "5",
+		"1.5e-3":  "0.0015",
+		"1.5E3":   "1500",
+		"-2e+2":   "-200",
+		"0.00e1":  "0.0",
+		"000.010": "0.010",
+	} {
+		d, err := TryParseDecimal(in)
+		if assert.NoError(t, err, in) {
+			assert.Equal(t, want, d.String(), in)
+		}
+	}
+	for _, in := range []string{"", "-", ".", "1.2.3", "1,5", "e5", "1e", "0x10", "1_000", " 1", "NaN"} {
+		_, err := TryParseDecimal(in)
+		assert.ErrorIs(t, err, strconv.ErrSyntax, in)
+	}
+	_, err := TryParseDecimal("1e999999999")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	_, err = TryParseDecimal("1e99999999999")
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	assert.Panics(t, func() { ParseDecimal("one") })
+}
@@ -0,0 +1,122 @@
+package oprs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Timer waits on the time, so that Retry can be tested without sleeping
+type Timer interface {
+	After(time.Duration) <-chan time.Time
+}
+
+// SystemTimer is the Timer backed by package time
+var SystemTimer Timer = systemTimer{}
+
+type systemTimer struct{}
+
+func (systemTimer) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Backoff returns how long to wait before the given retry, counting from 1
+type Backoff func(retry int) time.Duration
+
+// ConstantBackoff waits the same duration before every retry
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff doubles the wait before every retry, starting from base
+// the wait never exceeds max, or the largest time.Duration if max is non-positive
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	if max <= 0 {
+		max = math.MaxInt64
+	}
+	return func(retry int) time.Duration {
+		d := base
+		for i := 1; i < retry && d < max; i++ {
+			if d > max/2 {
+				return max
+			}
+			d *= 2
+		}
+		return min(d, max)
+	}
+}
+
+// Jitter randomises a backoff by scaling each wait by a factor drawn from rnd
+// rnd should return values in [0, 1), and defaults to math/rand's Float64 if nil
+func Jitter(b Backoff, rnd func() float64) Backoff {
+	if rnd == nil {
+		rnd = rand.Float64
+	}
+	return func(retry int) time.Duration {
+		return time.Duration(float64(b(retry)) * rnd())
+	}
+}
+
+// RetryPolicy configures Retry
+// The zero value makes a single attempt
+type RetryPolicy struct {
+	Attempts  int              // total number of attempts, including the first
+	Backoff   Backoff          // wait between attempts. no wait if nil
+	Retryable func(error) bool // decides which errors are worth retrying. all of them if nil
+	Timer     Timer            // used to wait between attempts. defaults to SystemTimer
+}
+
+// RetryError carries the error of every attempt made by Retry
+type RetryError struct {
+	Errs []error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("oprs.retry: gave up after %d attempt(s): %v", len(e.Errs), e.Errs[len(e.Errs)-1])
+}
+
+// Unwrap exposes every attempt's error to errors.Is and errors.As
+func (e *RetryError) Unwrap() []error {
+	return e.Errs
+}
+
+// Retry wraps a fallible function so that it is re-attempted according to the given policy
+// It gives up early if the error is not retryable, reporting the error of each attempt
+// in a *RetryError. If the context is done, its error is returned, wrapped with the
+// last attempt's error, if any
+func Retry[I, O any](f func(I) (O, error), policy RetryPolicy) func(context.Context, I) (O, error) {
+	Denull(&policy.Timer, SystemTimer)
+	return func(ctx context.Context, arg I) (O, error) {
+		var errs []error
+		for attempt := 1; ; attempt++ {
+			if ctx.Err() != nil {
+				return *new(O), retryCancelled(ctx, errs)
+			}
+			out, err := f(arg)
+			if err == nil {
+				return out, nil
+			}
+			errs = append(errs, err)
+			if attempt >= policy.Attempts || (policy.Retryable != nil && !policy.Retryable(err)) {
+				return *new(O), &RetryError{errs}
+			}
+			if policy.Backoff != nil {
+				select {
+				case <-policy.Timer.After(policy.Backoff(attempt)):
+				case <-ctx.Done():
+					return *new(O), retryCancelled(ctx, errs)
+				}
+			}
+		}
+	}
+}
+
+// retryCancelled reports a done context, along with the error of the last attempt
+func retryCancelled(ctx context.Context, errs []error) error {
+	if len(errs) == 0 {
+		return fmt.Errorf("oprs.retry: %w before the first attempt", ctx.Err())
+	}
+	return fmt.Errorf("oprs.retry: %w after %d attempt(s): %w", ctx.Err(), len(errs), errs[len(errs)-1])
+}
@@ -0,0 +1,104 @@
+package oprs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errFlaky = errors.New("flaky")
+
+// fakeTimer never sleeps, it records each wait and fires at once
+type fakeTimer struct {
+	sleeps []time.Duration
+}
+
+func (c *fakeTimer) After(d time.Duration) <-chan time.Time {
+	c.sleeps = append(c.sleeps, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func flaky(failures int) func(int) (int, error) {
+	return func(i int) (int, error) {
+		if failures > 0 {
+			failures--
+			return 0, errFlaky
+		}
+		return i, nil
+	}
+}
+
+func TestRetry(t *testing.T) {
+	timer := &fakeTimer{}
+	policy := RetryPolicy{Attempts: 5, Backoff: ExponentialBackoff(time.Second, 5*time.Second), Timer: timer}
+
+	out, err := Retry(flaky(4), policy)(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, out)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}, timer.sleeps)
+
+	_, err = Retry(flaky(5), policy)(context.Background(), 7)
+	var rerr *RetryError
+	assert.ErrorAs(t, err, &rerr)
+	assert.Len(t, rerr.Errs, 5)
+	assert.ErrorIs(t, err, errFlaky)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	timer := &fakeTimer{}
+	policy := RetryPolicy{
+		Attempts:  5,
+		Backoff:   Jitter(ConstantBackoff(time.Second), func() float64 { return 0.5 }),
+		Retryable: Not(Bind(errors.Is, context.DeadlineExceeded)),
+		Timer:     timer,
+	}
+	_, err := Retry(func(int) (int, error) { return 0, context.DeadlineExceeded }, policy)(context.Background(), 0)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, timer.sleeps)
+
+	_, err = Retry(flaky(1), policy)(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second / 2}, timer.sleeps)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Retry(flaky(0), policy)(ctx, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+	var rerr *RetryError
+	assert.False(t, errors.As(err, &rerr), "a cancellation is not an attempt")
+}
+
+func TestRetryCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	f := func(int) (int, error) {
+		if attempts++; attempts == 2 {
+			cancel()
+		}
+		return 0, errFlaky
+	}
+	_, err := Retry(f, RetryPolicy{Attempts: 5, Timer: &fakeTimer{}, Backoff: ConstantBackoff(time.Second)})(ctx, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, errFlaky)
+	assert.EqualError(t, err, "oprs.retry: context canceled after 2 attempt(s): flaky")
+	assert.Equal(t, 2, attempts)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff(time.Second, 0)
+	assert.Equal(t, 8*time.Second, b(4))
+	prev := time.Duration(0)
+	for retry := 1; retry < 200; retry++ {
+		d := b(retry)
+		assert.GreaterOrEqual(t, d, prev, "retry %d", retry)
+		prev = d
+	}
+	assert.Equal(t, time.Duration(math.MaxInt64), b(100))
+	assert.Equal(t, 3*time.Second, ExponentialBackoff(time.Second, 3*time.Second)(100))
+}
@@ -0,0 +1,157 @@
+// Package seq offers lazy, range-over-func counterparts to the slice tools in oprs
+//
+// Stages such as Map, Filter and Take are curried so that they can be
+// composed with oprs.Pipe before any sequence is supplied
+package seq
+
+import "iter"
+
+// Of returns a sequence over the given values
+func Of[T any](vals ...T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range vals {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Map transforms a function between two types into one between sequences of their instances
+// it is the lazy counterpart of oprs.Integrate
+func Map[I, O any](f func(I) O) func(iter.Seq[I]) iter.Seq[O] {
+	return func(s iter.Seq[I]) iter.Seq[O] {
+		return func(yield func(O) bool) {
+			for v := range s {
+				if !yield(f(v)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Filter returns a stage that only yields the elements satisfying the given predicate
+func Filter[T any](pred func(T) bool) func(iter.Seq[T]) iter.Seq[T] {
+	return func(s iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			for v := range s {
+				if pred(v) && !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Take returns a stage that yields, at most, the first n elements
+func Take[T any](n int) func(iter.Seq[T]) iter.Seq[T] {
+	return func(s iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			if n <= 0 {
+				return
+			}
+			i := 0
+			for v := range s {
+				if !yield(v) {
+					return
+				}
+				if i++; i == n {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Drop returns a stage that skips the first n elements
+func Drop[T any](n int) func(iter.Seq[T]) iter.Seq[T] {
+	return func(s iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			i := 0
+			for v := range s {
+				if i < n {
+					i++
+					continue
+				}
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// TakeWhile returns a stage that yields elements until the predicate first fails
+func TakeWhile[T any](pred func(T) bool) func(iter.Seq[T]) iter.Seq[T] {
+	return func(s iter.Seq[T]) iter.Seq[T] {
+		return func(yield func(T) bool) {
+			for v := range s {
+				if !pred(v) || !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Zip pairs the elements of two sequences, stopping with the shorter one
+func Zip[L, R any](left iter.Seq[L], right iter.Seq[R]) iter.Seq2[L, R] {
+	return func(yield func(L, R) bool) {
+		next, stop := iter.Pull(right)
+		defer stop()
+		for l := range left {
+			r, ok := next()
+			if !ok || !yield(l, r) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate pairs each element of a sequence with its index
+func Enumerate[T any](s iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range s {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Chain concatenates sequences
+func Chain[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, s := range seqs {
+			for v := range s {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Flatten concatenates a sequence of sequences
+func Flatten[T any](seqs iter.Seq[iter.Seq[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for s := range seqs {
+			for v := range s {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Collect gathers the elements of a sequence into a slice
+func Collect[T any](s iter.Seq[T]) (out []T) {
+	for v := range s {
+		out = append(out, v)
+	}
+	return out
+}
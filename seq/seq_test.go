@@ -0,0 +1,43 @@
+package seq
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kendfss/oprs"
+)
+
+func naturals() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	var double oprs.Op[int] = func(i int) int { return i * 2 }
+	stages := oprs.Pipe(
+		oprs.Pipe(Filter(oprs.IsOdd[int]), Map(double)),
+		Take[int](4),
+	)
+	assert.Equal(t, []int{2, 6, 10, 14}, Collect(stages(naturals())))
+	assert.Equal(t, []int{3, 4}, Collect(Drop[int](3)(TakeWhile(oprs.Bind(oprs.Lt[int], 5))(naturals()))))
+	assert.Nil(t, Collect(Take[int](0)(naturals())))
+}
+
+func TestCombinators(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3, 4}, Collect(Chain(Of(1, 2), Of[int](), Of(3, 4))))
+	assert.Equal(t, []int{1, 2, 3}, Collect(Flatten(Of(Of(1), Of(2, 3)))))
+
+	var pairs []string
+	for i, s := range Zip(naturals(), Of("a", "b")) {
+		pairs = append(pairs, oprs.ToString(i)+s)
+	}
+	assert.Equal(t, []string{"0a", "1b"}, pairs)
+
+	for i, v := range Enumerate(Of(5, 6, 7)) {
+		assert.Equal(t, i+5, v)
+	}
+}